@@ -0,0 +1,129 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// DefaultSlowValidatorWorkers is the default number of slow validators run at the same time by a validation
+const DefaultSlowValidatorWorkers = 8
+
+var slowValidatorWorkers = struct {
+	sync.RWMutex
+	n int
+}{n: DefaultSlowValidatorWorkers}
+
+// SetSlowValidatorWorkers sets the number of slow validators (see EmValidator.IsSlow) run at the same time by a
+// single validation
+func SetSlowValidatorWorkers(n int) error {
+	if n < 1 {
+		return fmt.Errorf("The number of slow validator workers must be positive; got %d", n)
+	}
+
+	slowValidatorWorkers.Lock()
+	slowValidatorWorkers.n = n
+	slowValidatorWorkers.Unlock()
+
+	return nil
+}
+
+func currentSlowValidatorWorkers() int {
+	slowValidatorWorkers.RLock()
+	defer slowValidatorWorkers.RUnlock()
+	return slowValidatorWorkers.n
+}
+
+// slowCheck is a slow validator found while walking the struct, run once the walk is done
+type slowCheck struct {
+	value     reflect.Value
+	field     reflect.StructField
+	validator FieldValidator
+	path      string
+
+	// position is the number of errors in the bag when the check was queued, which is where its error goes
+	position int
+}
+
+type slowResult struct {
+	valid bool
+	err   error
+}
+
+// deferCheck queues a slow validator, to be run by runSlowChecks
+func (eb *ErrorBag) deferCheck(v reflect.Value, t reflect.StructField, validator FieldValidator, path string) {
+	eb.pending = append(eb.pending, slowCheck{value: v, field: t, validator: validator, path: path, position: len(eb.ordered)})
+}
+
+// runSlowChecks runs the slow validators queued in bag, at most currentSlowValidatorWorkers() at a time. Their
+// errors are inserted where the validators were queued, so the bag stays in struct field order whatever order they
+// finish in.
+// If a check fails or ctx is done before every check has run, the error of the first check in field order is
+// returned.
+func runSlowChecks(ctx context.Context, bag *ErrorBag, err error) (*ErrorBag, error) {
+	if bag == nil {
+		return bag, err
+	}
+	checks := bag.pending
+	bag.pending = nil
+	if err != nil || len(checks) == 0 {
+		return bag, err
+	}
+
+	results := make([]slowResult, len(checks))
+	workers := make(chan struct{}, currentSlowValidatorWorkers())
+	var wg sync.WaitGroup
+
+	for i := range checks {
+		select {
+		case workers <- struct{}{}:
+		case <-ctx.Done():
+			results[i].err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			check := &checks[i]
+			valid, err := check.validator.check(ctx, check.value)
+			if err == nil && ctx.Err() != nil {
+				// don't trust a result that came in after the deadline
+				err = ctx.Err()
+			}
+			results[i] = slowResult{valid: valid, err: err}
+		}(i)
+	}
+	wg.Wait()
+
+	for i, check := range checks {
+		if results[i].err != nil {
+			return bag, fmt.Errorf("Error validating %s: %s", check.field.Name, results[i].err.Error())
+		}
+	}
+
+	fast := bag.ordered
+	bag.errors = make(map[string][]Error)
+	bag.ordered = make([]Error, 0, len(fast))
+	next := 0
+	for i, check := range checks {
+		if results[i].valid {
+			continue
+		}
+		for _, e := range fast[next:check.position] {
+			bag.addError(e)
+		}
+		next = check.position
+		bag.addError(check.validator.newError(errorKey(check.field), check.path, bag.locale))
+	}
+	for _, e := range fast[next:] {
+		bag.addError(e)
+	}
+
+	return bag, nil
+}
@@ -0,0 +1,128 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// takenUsernames stands in for a uniqueness query. Shorter names take longer to check, so checks finish in the
+// reverse of field order.
+var takenUsernames = map[string]bool{"ann": true, "bob": true, "carla": true}
+
+var runningChecks, maxRunningChecks int32
+
+func init() {
+	err := RegisterValidator("testunique", EmValidator{
+		IsSlow: true,
+		OpContext: func(ctx context.Context, val interface{}, params ...interface{}) (bool, error) {
+			running := atomic.AddInt32(&runningChecks, 1)
+			defer atomic.AddInt32(&runningChecks, -1)
+			for {
+				max := atomic.LoadInt32(&maxRunningChecks)
+				if running <= max || atomic.CompareAndSwapInt32(&maxRunningChecks, max, running) {
+					break
+				}
+			}
+
+			name := val.(string)
+			select {
+			case <-time.After(time.Duration(20-len(name)) * time.Millisecond):
+			case <-ctx.Done():
+				return false, ctx.Err()
+			}
+			return !takenUsernames[name], nil
+		},
+	})
+	if err != nil {
+		panic(err.Error())
+	}
+
+	err = RegisterValidator("testunreachable", EmValidator{
+		IsSlow: true,
+		OpContext: func(ctx context.Context, val interface{}, params ...interface{}) (bool, error) {
+			return false, errors.New("database is down")
+		},
+	})
+	if err != nil {
+		panic(err.Error())
+	}
+}
+
+type Team struct {
+	Captain string   `json:"captain" valid:"required|testunique"`
+	Members []string `json:"members" valid:"testunique"`
+	Coach   string   `json:"coach" valid:"required|testunique"`
+}
+
+func TestSlowValidatorsMergeInFieldOrder(t *testing.T) {
+	t.Parallel()
+
+	team := Team{Captain: "carla", Members: []string{"dave", "ann", "eve", "bob"}}
+	for i := 0; i < 5; i++ {
+		bag, err := ValidateStruct(team)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"captain:testunique", "members[1]:testunique", "members[3]:testunique", "coach:required"}, embeddedErrorPaths(bag))
+	}
+}
+
+func TestSlowValidatorErrorsKeepTagOrder(t *testing.T) {
+	t.Parallel()
+
+	type Signup struct {
+		Username string `json:"username" valid:"testunique|between(5,10)"`
+		Email    string `json:"email" valid:"required"`
+		Nickname string `json:"nickname" valid:"between(5,10)|testunique"`
+	}
+
+	bag, err := ValidateStruct(Signup{Username: "bob", Nickname: "ann"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"username:testunique", "username:between", "email:required", "nickname:between",
+		"nickname:testunique"}, embeddedErrorPaths(bag))
+
+	errs := bag.ErrorMap()["username"]
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "testunique", errs[0].Validator)
+	assert.Equal(t, "between", errs[1].Validator)
+}
+
+func TestSlowValidatorWorkersAreBounded(t *testing.T) {
+	assert.NotNil(t, SetSlowValidatorWorkers(0))
+	assert.Nil(t, SetSlowValidatorWorkers(2))
+	defer SetSlowValidatorWorkers(DefaultSlowValidatorWorkers)
+
+	atomic.StoreInt32(&maxRunningChecks, 0)
+	_, err := ValidateSlice([]string{"a", "b", "c", "d", "e", "f"}, "testunique")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunningChecks))
+}
+
+func TestSlowValidatorsStopAtDeadline(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	_, err := ValidateStructContext(ctx, Team{Captain: "zoe", Coach: "yann"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), context.DeadlineExceeded.Error())
+}
+
+func TestSlowValidatorErrorIsInternalError(t *testing.T) {
+	t.Parallel()
+
+	_, err := ValidateVar("zoe", "testunreachable")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "database is down")
+}
+
+func TestRegisterValidator(t *testing.T) {
+	t.Parallel()
+
+	assert.NotNil(t, RegisterValidator("", EmValidator{OpString: IsEmail}))
+	assert.NotNil(t, RegisterValidator("bad|key", EmValidator{OpString: IsEmail}))
+	assert.NotNil(t, RegisterValidator("noop", EmValidator{}))
+}
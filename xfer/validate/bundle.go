@@ -0,0 +1,347 @@
+package validate
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the last locale of every fallback chain. Its bundle is built from messages_en.
+const DefaultLocale = `en`
+
+// plural categories, named after the CLDR plural categories
+const (
+	PluralZero  = `zero`
+	PluralOne   = `one`
+	PluralTwo   = `two`
+	PluralFew   = `few`
+	PluralMany  = `many`
+	PluralOther = `other`
+)
+
+//go:embed locales/*.json
+var embeddedLocales embed.FS
+
+// PluralRule returns the plural category for a count
+type PluralRule func(n float64) string
+
+// bundleMessage is a single bundle entry. In bundle files it's either a plain string or an object holding one
+// message per plural category, plus the name of the validator parameter that selects the category:
+//
+//	"between.message": {"count": "max", "one": "{field} must be at most {max} character", "other": "..."}
+type bundleMessage struct {
+	Text   string
+	Count  string
+	Plural map[string]string
+}
+
+func (bm *bundleMessage) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &bm.Text); err == nil {
+		return nil
+	}
+
+	var forms map[string]string
+	if err := json.Unmarshal(data, &forms); err != nil {
+		return fmt.Errorf("a message must be a string or an object of plural forms")
+	}
+
+	bm.Count = forms["count"]
+	delete(forms, "count")
+	if len(bm.Count) == 0 {
+		return fmt.Errorf("plural messages must name a count parameter")
+	}
+	if _, ok := forms[PluralOther]; !ok {
+		return fmt.Errorf("plural messages must have an %q form", PluralOther)
+	}
+	bm.Plural = forms
+
+	return nil
+}
+
+// form returns the message text for a plural category, falling back to the "other" form
+func (bm bundleMessage) form(category string) string {
+	if bm.Plural == nil {
+		return bm.Text
+	}
+	if msg, ok := bm.Plural[category]; ok {
+		return msg
+	}
+	return bm.Plural[PluralOther]
+}
+
+// texts returns the message text, or all of its plural forms
+func (bm bundleMessage) texts() []string {
+	if bm.Plural == nil {
+		return []string{bm.Text}
+	}
+	texts := make([]string, 0, len(bm.Plural))
+	for _, text := range bm.Plural {
+		texts = append(texts, text)
+	}
+	return texts
+}
+
+// MessageBundle holds the messages for a single locale. Keys use the same compound format as messages_en
+// (validatorkey.messagetype).
+type MessageBundle struct {
+	Locale   string
+	messages map[string]bundleMessage
+}
+
+// NewMessageBundle creates a bundle from plain (non-plural) messages
+func NewMessageBundle(locale string, messages map[string]string) *MessageBundle {
+	b := &MessageBundle{Locale: normalizeLocale(locale), messages: make(map[string]bundleMessage, len(messages))}
+	for key, msg := range messages {
+		b.messages[key] = bundleMessage{Text: msg}
+	}
+	return b
+}
+
+// ParseMessageBundle parses a JSON message bundle
+func ParseMessageBundle(locale string, data []byte) (*MessageBundle, error) {
+	b := &MessageBundle{Locale: normalizeLocale(locale)}
+	if err := json.Unmarshal(data, &b.messages); err != nil {
+		return nil, fmt.Errorf("Invalid message bundle for locale %s: %s", locale, err.Error())
+	}
+
+	for key, msg := range b.messages {
+		if _, err := splitMessageKey(key); err != nil {
+			return nil, fmt.Errorf("Invalid message bundle for locale %s: %s", locale, err.Error())
+		}
+		for _, text := range msg.texts() {
+			if err := checkPlaceholderSyntax(text); err != nil {
+				return nil, fmt.Errorf("Invalid message bundle for locale %s: %s in %s", locale, err.Error(), key)
+			}
+		}
+	}
+
+	return b, nil
+}
+
+// Keys returns the message keys defined in the bundle
+func (b *MessageBundle) Keys() []string {
+	keys := make([]string, 0, len(b.messages))
+	for key := range b.messages {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+type bundleRegistry struct {
+	sync.RWMutex
+	bundles     map[string]*MessageBundle
+	pluralRules map[string]PluralRule
+}
+
+var bundles = newBundleRegistry()
+
+func newBundleRegistry() *bundleRegistry {
+	r := &bundleRegistry{
+		bundles: make(map[string]*MessageBundle),
+		pluralRules: map[string]PluralRule{
+			`en`: pluralOneOther,
+			`de`: pluralOneOther,
+			`es`: pluralOneOther,
+			`fr`: pluralFrench,
+		},
+	}
+
+	r.add(NewMessageBundle(DefaultLocale, messages_en))
+
+	if err := r.load(embeddedLocales, `locales`); err != nil {
+		panic(err.Error())
+	}
+
+	return r
+}
+
+// add merges b into the bundle already registered for its locale, if any
+func (r *bundleRegistry) add(b *MessageBundle) {
+	r.Lock()
+	defer r.Unlock()
+
+	existing, ok := r.bundles[b.Locale]
+	if !ok {
+		existing = &MessageBundle{Locale: b.Locale, messages: make(map[string]bundleMessage, len(b.messages))}
+		r.bundles[b.Locale] = existing
+	}
+	for key, msg := range b.messages {
+		existing.messages[key] = msg
+	}
+	resetMessageChecks()
+}
+
+func (r *bundleRegistry) load(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+
+		b, err := ParseMessageBundle(strings.TrimSuffix(path.Base(file), ".json"), data)
+		if err != nil {
+			return err
+		}
+		r.add(b)
+	}
+
+	return nil
+}
+
+// supports returns true if there's a bundle for the locale or one of its parents. Falling back to the default
+// locale doesn't count.
+func (r *bundleRegistry) supports(locale string) bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	for _, l := range localeParents(locale) {
+		if _, ok := r.bundles[l]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup walks the fallback chain of locale and returns the first message found for one of the keys. Keys are
+// tried in order within each locale before moving on to the next locale in the chain.
+func (r *bundleRegistry) lookup(locale string, keys ...string) (bundleMessage, string, bool) {
+	r.RLock()
+	defer r.RUnlock()
+
+	for _, l := range localeChain(locale) {
+		b, ok := r.bundles[l]
+		if !ok {
+			continue
+		}
+		for _, key := range keys {
+			if msg, ok := b.messages[key]; ok {
+				return msg, key, true
+			}
+		}
+	}
+
+	return bundleMessage{}, ``, false
+}
+
+// templates returns the texts of every registered locale for the keys, including plural forms
+func (r *bundleRegistry) templates(keys ...string) []string {
+	r.RLock()
+	defer r.RUnlock()
+
+	var texts []string
+	for _, b := range r.bundles {
+		for _, key := range keys {
+			if msg, ok := b.messages[key]; ok {
+				texts = append(texts, msg.texts()...)
+			}
+		}
+	}
+	return texts
+}
+
+func (r *bundleRegistry) pluralCategory(locale string, n float64) string {
+	r.RLock()
+	defer r.RUnlock()
+
+	for _, l := range localeChain(locale) {
+		if rule, ok := r.pluralRules[l]; ok {
+			return rule(n)
+		}
+	}
+	return pluralOneOther(n)
+}
+
+// RegisterMessageBundle adds a bundle. Messages are merged into any bundle already registered for the same locale,
+// so an application can override single messages without redefining the whole locale.
+func RegisterMessageBundle(b *MessageBundle) {
+	bundles.add(b)
+}
+
+// LoadMessageBundles registers every <locale>.json bundle found in dir
+func LoadMessageBundles(fsys fs.FS, dir string) error {
+	return bundles.load(fsys, dir)
+}
+
+// RegisterPluralRule sets the plural rule for a locale or language. Locales without a rule use their language's
+// rule, or the English one/other rule if there is none.
+func RegisterPluralRule(locale string, rule PluralRule) {
+	bundles.Lock()
+	defer bundles.Unlock()
+	bundles.pluralRules[normalizeLocale(locale)] = rule
+}
+
+func pluralOneOther(n float64) string {
+	if n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func pluralFrench(n float64) string {
+	if n >= 0 && n < 2 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// normalizeLocale converts de_at and DE-at to de-AT
+func normalizeLocale(locale string) string {
+	parts := strings.Split(strings.Replace(locale, "_", "-", -1), "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		if len(parts[i]) == 2 {
+			parts[i] = strings.ToUpper(parts[i])
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// localeParents returns a locale followed by its parents, e.g. de-AT -> de
+func localeParents(locale string) []string {
+	locale = normalizeLocale(locale)
+	parents := make([]string, 0, 3)
+	for len(locale) > 0 {
+		parents = append(parents, locale)
+		idx := strings.LastIndex(locale, "-")
+		if idx < 0 {
+			break
+		}
+		locale = locale[:idx]
+	}
+	return parents
+}
+
+// localeChain returns the fallback chain for a locale, e.g. de-AT -> de -> en
+func localeChain(locale string) []string {
+	chain := localeParents(locale)
+	if len(chain) == 0 || chain[len(chain)-1] != DefaultLocale {
+		chain = append(chain, DefaultLocale)
+	}
+	return chain
+}
+
+// splitMessageKey extracts the message type from the compound key format. The key before the type may have dots
+// itself, as reason messages do (password.minlength.message).
+func splitMessageKey(fullKey string) (string, error) {
+	idx := strings.LastIndex(fullKey, ".")
+	if idx < 1 {
+		return ``, fmt.Errorf("%s is not a valid message key", fullKey)
+	}
+
+	messageType := fullKey[idx+1:]
+	switch messageType {
+	case `messagefmt`, `negatedmessagefmt`, `message`, `negatedmessage`:
+		return messageType, nil
+	}
+
+	return ``, fmt.Errorf("%s is not a valid message type identifier", messageType)
+}
@@ -0,0 +1,151 @@
+package validate
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"testing/fstest"
+)
+
+func TestBundlesCoverEnglishMessages(t *testing.T) {
+	t.Parallel()
+
+	for _, locale := range []string{`de`, `fr`, `es`} {
+		bundles.RLock()
+		b := bundles.bundles[locale]
+		bundles.RUnlock()
+
+		for key := range messages_en {
+			_, found := b.messages[key]
+			assert.True(t, found, key+" is missing from the "+locale+" bundle")
+		}
+	}
+}
+
+func TestLocaleChain(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{`de-AT`, `de`, `en`}, localeChain(`de_at`))
+	assert.Equal(t, []string{`fr`, `en`}, localeChain(`FR`))
+	assert.Equal(t, []string{`en-GB`, `en`}, localeChain(`en-GB`))
+	assert.Equal(t, []string{`en`}, localeChain(``))
+}
+
+func TestLocalizedMessageFallback(t *testing.T) {
+	t.Parallel()
+
+	RegisterMessageBundle(NewMessageBundle(`de-CH`, map[string]string{
+		`email.messagefmt`: `%s muess e gültigi Adrässe sii`,
+	}))
+
+	email := FieldValidator{FieldName: "Email", ValidatorKey: "email"}
+	assert.Equal(t, "Email muess e gültigi Adrässe sii", email.MessageIn(`de-CH`))
+
+	// falls back to de
+	msg, _ := localizedMessage(`de-CH`, "email", true, email.placeholderValues(`de-CH`))
+	assert.Equal(t, "Email darf keine E-Mail-Adresse sein", msg)
+
+	// not in any bundle
+	_, found := localizedMessage(`de-CH`, "isbn10", false, email.placeholderValues(`de-CH`))
+	assert.False(t, found)
+}
+
+func TestLocalizedMessageNamedParams(t *testing.T) {
+	t.Parallel()
+
+	v, _ := GetValidator("between")
+	between := FieldValidator{FieldName: "Age", ValidatorKey: "between", Validator: *v, ValidatorParams: []interface{}{"18", "99"}}
+
+	assert.Equal(t, "Age must be between 18 and 99", between.MessageIn(`en`))
+	assert.Equal(t, "Age doit être compris entre 18 et 99", between.MessageIn(`fr-CA`))
+}
+
+func TestLocalizedMessagePlurals(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"bundles/pl.json": &fstest.MapFile{Data: []byte(`{
+			"between.negatedmessage": {
+				"count": "max",
+				"one": "{field} nie może mieć {max} znaku",
+				"other": "{field} nie może mieć od {min} do {max} znaków"
+			}
+		}`)},
+	}
+	assert.Nil(t, LoadMessageBundles(fsys, "bundles"))
+	RegisterPluralRule(`pl`, func(n float64) string {
+		if n == 1 {
+			return PluralOne
+		}
+		return PluralOther
+	})
+
+	v, _ := GetValidator("between")
+	between := FieldValidator{FieldName: "Nazwa", ValidatorKey: "between", Validator: *v}
+
+	between.ValidatorParams = []interface{}{"1", "1"}
+	msg, _ := localizedMessage(`pl`, "between", true, between.placeholderValues(`pl`))
+	assert.Equal(t, "Nazwa nie może mieć 1 znaku", msg)
+
+	between.ValidatorParams = []interface{}{"2", "10"}
+	msg, _ = localizedMessage(`pl`, "between", true, between.placeholderValues(`pl`))
+	assert.Equal(t, "Nazwa nie może mieć od 2 do 10 znaków", msg)
+}
+
+func TestParseMessageBundleErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseMessageBundle(`xx`, []byte(`{"email.wrongtype": "x"}`))
+	assert.NotNil(t, err)
+
+	_, err = ParseMessageBundle(`xx`, []byte(`{"email.message": {"one": "x", "other": "y"}}`))
+	assert.NotNil(t, err, "plural messages need a count param")
+
+	_, err = ParseMessageBundle(`xx`, []byte(`{"email.message": {"count": "max", "one": "x"}}`))
+	assert.NotNil(t, err, "plural messages need an other form")
+}
+
+func TestSetMessagesLocale(t *testing.T) {
+	assert.NotNil(t, SetMessagesLocale(`xx`))
+	assert.Equal(t, DefaultLocale, currentMessagesLocale())
+
+	assert.Nil(t, SetMessagesLocale(`es-MX`))
+	defer SetMessagesLocale(DefaultLocale)
+
+	type Signup struct {
+		Email string `valid:"email"`
+	}
+	bag, err := ValidateStruct(Signup{Email: "nope"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Email: Email debe ser una dirección válida", bag.Errors()[0].Error())
+}
+
+func TestValidateStructContextLocale(t *testing.T) {
+	t.Parallel()
+
+	type Signup struct {
+		Email string `valid:"email"`
+		Name  string `valid:"between(2,10)"`
+		Code  string `valid:"alpha->{field} is not a code"`
+	}
+	signup := Signup{Email: "nope", Name: "x", Code: "123"}
+
+	bag, err := ValidateStructContext(WithLocale(context.Background(), `de-AT`), signup)
+	assert.Nil(t, err)
+	assert.Equal(t, `de-AT`, bag.Locale())
+	assert.Equal(t, 3, len(bag.Errors()))
+	assert.Contains(t, bag.Error(), "Email muss eine gültige Adresse sein")
+	assert.Contains(t, bag.Error(), "Name muss zwischen 2 und 10 liegen")
+	assert.Contains(t, bag.Error(), "Code is not a code")
+
+	// the same bag, rendered in another language
+	fr := bag.Localize(`fr`)
+	assert.Equal(t, `fr`, fr.Locale())
+	assert.Contains(t, fr.Error(), "Email doit être une adresse valide")
+	assert.Contains(t, fr.Error(), "Name doit être compris entre 2 et 10")
+	assert.Contains(t, fr.Error(), "Code is not a code")
+
+	// the original bag is untouched, and other goroutines still get the default locale
+	assert.Contains(t, bag.Error(), "Email muss eine gültige Adresse sein")
+	assert.Equal(t, DefaultLocale, LocaleFromContext(context.Background()))
+}
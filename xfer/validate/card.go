@@ -0,0 +1,204 @@
+package validate
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Card brands detected by CardBrandOf
+const (
+	CardVisa       = "visa"
+	CardMastercard = "mastercard"
+	CardAmex       = "amex"
+	CardDiscover   = "discover"
+	CardJCB        = "jcb"
+	CardUnionPay   = "unionpay"
+	CardDinersClub = "dinersclub"
+	CardMaestro    = "maestro"
+	CardMir        = "mir"
+)
+
+// cardRange is a range of card number prefixes, all with the same number of digits
+type cardRange struct {
+	from, to string
+}
+
+// cardBrand describes the numbers issued by a brand: their prefix ranges (IIN/BIN), lengths and CVC length
+type cardBrand struct {
+	ranges    []cardRange
+	lengths   []int
+	cvcLength int
+}
+
+var cardBrands = map[string]cardBrand{
+	CardVisa: {
+		ranges:    []cardRange{{"4", "4"}},
+		lengths:   []int{13, 16, 19},
+		cvcLength: 3,
+	},
+	CardMastercard: {
+		ranges:    []cardRange{{"51", "55"}, {"2221", "2720"}},
+		lengths:   []int{16},
+		cvcLength: 3,
+	},
+	CardAmex: {
+		ranges:    []cardRange{{"34", "34"}, {"37", "37"}},
+		lengths:   []int{15},
+		cvcLength: 4,
+	},
+	CardDiscover: {
+		ranges:    []cardRange{{"6011", "6011"}, {"644", "649"}, {"65", "65"}, {"622126", "622925"}},
+		lengths:   []int{16, 17, 18, 19},
+		cvcLength: 3,
+	},
+	CardJCB: {
+		ranges:    []cardRange{{"3528", "3589"}},
+		lengths:   []int{16, 17, 18, 19},
+		cvcLength: 3,
+	},
+	CardUnionPay: {
+		ranges:    []cardRange{{"62", "62"}},
+		lengths:   []int{16, 17, 18, 19},
+		cvcLength: 3,
+	},
+	CardDinersClub: {
+		ranges:    []cardRange{{"300", "305"}, {"3095", "3095"}, {"36", "36"}, {"38", "39"}},
+		lengths:   []int{14, 15, 16, 17, 18, 19},
+		cvcLength: 3,
+	},
+	CardMaestro: {
+		ranges:    []cardRange{{"5018", "5018"}, {"5020", "5020"}, {"5038", "5038"}, {"5893", "5893"}, {"6304", "6304"}, {"6759", "6759"}, {"6761", "6763"}},
+		lengths:   []int{12, 13, 14, 15, 16, 17, 18, 19},
+		cvcLength: 3,
+	},
+	CardMir: {
+		ranges:    []cardRange{{"2200", "2204"}},
+		lengths:   []int{16, 17, 18, 19},
+		cvcLength: 3,
+	},
+}
+
+var (
+	rxCardNumber = regexp.MustCompile(`^[0-9]{12,19}$`)
+	rxCardExpiry = regexp.MustCompile(`^(0[1-9]|1[0-2]) ?/ ?([0-9]{2})$`)
+	rxCVC        = regexp.MustCompile(`^[0-9]{3,4}$`)
+)
+
+// sanitizeCardNumber removes the spaces and dashes card numbers are usually grouped with
+func sanitizeCardNumber(str string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(str)
+}
+
+// CardBrandOf returns the brand of a card number (one of the Card* constants), detected from its prefix. When
+// ranges overlap, the most specific prefix wins, e.g. 622126 is Discover rather than UnionPay. The length and check
+// digit are not verified; use IsCreditCard for that.
+func CardBrandOf(number string) (string, bool) {
+	number = sanitizeCardNumber(number)
+
+	brand, prefixLength := "", 0
+	for name, b := range cardBrands {
+		for _, r := range b.ranges {
+			n := len(r.from)
+			if n <= prefixLength || len(number) < n {
+				continue
+			}
+			if prefix := number[:n]; prefix >= r.from && prefix <= r.to {
+				brand, prefixLength = name, n
+			}
+		}
+	}
+
+	return brand, prefixLength > 0
+}
+
+// IsCreditCard check if the string is a credit card number: a known brand, a valid length for the brand and a valid
+// Luhn check digit. Spaces and dashes between digit groups are allowed. Brand names can be given as params to only
+// accept those brands, e.g. creditcard(visa,mastercard). Empty string is valid.
+func IsCreditCard(str string, params ...interface{}) bool {
+	if IsNull(str) {
+		return true
+	}
+
+	number := sanitizeCardNumber(str)
+	if !rxCardNumber.MatchString(number) {
+		return false
+	}
+
+	brand, ok := CardBrandOf(number)
+	if !ok || !isAllowedCardBrand(brand, params) {
+		return false
+	}
+
+	lengthOK := false
+	for _, length := range cardBrands[brand].lengths {
+		lengthOK = lengthOK || len(number) == length
+	}
+
+	return lengthOK && luhn(number)
+}
+
+func isAllowedCardBrand(brand string, params []interface{}) bool {
+	if len(params) == 0 {
+		return true
+	}
+	for _, param := range params {
+		if name, ok := param.(string); ok && strings.ToLower(strings.TrimSpace(name)) == brand {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCardExpiry check if the string is a card expiry date formatted as MM/YY that hasn't passed. Cards expire at the
+// end of their expiry month. Empty string is valid.
+func IsCardExpiry(str string, params ...interface{}) bool {
+	if IsNull(str) {
+		return true
+	}
+
+	matches := rxCardExpiry.FindStringSubmatch(str)
+	if matches == nil {
+		return false
+	}
+	month, _ := strconv.Atoi(matches[1])
+	year, _ := strconv.Atoi(matches[2])
+
+	now := time.Now()
+	// YY is the closest year to now
+	year += now.Year() / 100 * 100
+	if year > now.Year()+50 {
+		year -= 100
+	} else if year < now.Year()-50 {
+		year += 100
+	}
+
+	return year > now.Year() || (year == now.Year() && time.Month(month) >= now.Month())
+}
+
+// IsCVC check if the string is a card security code. The param is the brand of the card or, for cvc(CardNumber),
+// its number, in which case the code must have the brand's length (4 digits for Amex, 3 for the others). Without a
+// param, or when the brand isn't known, 3 and 4 digit codes are accepted. Empty string is valid.
+func IsCVC(str string, params ...interface{}) bool {
+	if IsNull(str) {
+		return true
+	}
+	if !rxCVC.MatchString(str) {
+		return false
+	}
+	if len(params) == 0 {
+		return true
+	}
+
+	param, _ := params[0].(string)
+	brand := strings.ToLower(strings.TrimSpace(param))
+	if _, ok := cardBrands[brand]; !ok {
+		brand, _ = CardBrandOf(param)
+	}
+	if b, ok := cardBrands[brand]; ok {
+		return len(str) == b.cvcLength
+	}
+
+	return true
+}
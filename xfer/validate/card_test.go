@@ -0,0 +1,113 @@
+package validate
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCardBrandOf(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		expected string
+	}{
+		{"4111111111111111", CardVisa},
+		{"5555555555554444", CardMastercard},
+		{"2223003122003222", CardMastercard},
+		{"378282246310005", CardAmex},
+		{"6011111111111117", CardDiscover},
+		{"6221260000000000", CardDiscover},
+		{"6200000000000005", CardUnionPay},
+		{"3530111333300000", CardJCB},
+		{"36227206271667", CardDinersClub},
+		{"6759649826438453", CardMaestro},
+		{"2200000000000004", CardMir},
+		{"9999999999999995", ""},
+	}
+	for _, test := range tests {
+		brand, ok := CardBrandOf(test.param)
+		assert.Equal(t, test.expected, brand, test.param)
+		assert.Equal(t, len(test.expected) > 0, ok, test.param)
+	}
+}
+
+func TestIsCreditCardBrands(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsCreditCard(""))
+	assert.True(t, IsCreditCard("2223 0031 2200 3222"))
+	assert.True(t, IsCreditCard("6200000000000005"))
+	assert.False(t, IsCreditCard("4111 1111 1111 111"))
+	assert.False(t, IsCreditCard("37828224631000"))
+	assert.False(t, IsCreditCard("4111x1111x1111x1111"))
+
+	assert.True(t, IsCreditCard("4111111111111111", "visa", "mastercard"))
+	assert.True(t, IsCreditCard("5555555555554444", "visa", " Mastercard"))
+	assert.False(t, IsCreditCard("378282246310005", "visa", "mastercard"))
+
+	type Checkout struct {
+		Card string `json:"card" valid:"creditcard(visa,mastercard)"`
+	}
+	bag, err := ValidateStruct(Checkout{Card: "378282246310005"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"card:creditcard"}, embeddedErrorPaths(bag))
+
+	bag, err = ValidateStruct(Checkout{})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+}
+
+func TestIsCardExpiry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	expiry := func(months int) string {
+		d := time.Date(now.Year(), now.Month()+time.Month(months), 1, 0, 0, 0, 0, time.Local)
+		return fmt.Sprintf("%02d/%02d", d.Month(), d.Year()%100)
+	}
+
+	assert.True(t, IsCardExpiry(""))
+	assert.True(t, IsCardExpiry(expiry(0)))
+	assert.True(t, IsCardExpiry(expiry(1)))
+	assert.True(t, IsCardExpiry(expiry(120)))
+	assert.False(t, IsCardExpiry(expiry(-1)))
+	assert.False(t, IsCardExpiry(expiry(-120)))
+	assert.False(t, IsCardExpiry("13/30"))
+	assert.False(t, IsCardExpiry("00/30"))
+	assert.False(t, IsCardExpiry("1/30"))
+	assert.False(t, IsCardExpiry("01/2030"))
+}
+
+func TestIsCVC(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsCVC(""))
+	assert.True(t, IsCVC("123"))
+	assert.True(t, IsCVC("1234"))
+	assert.False(t, IsCVC("12"))
+	assert.False(t, IsCVC("12a"))
+
+	assert.True(t, IsCVC("1234", "amex"))
+	assert.False(t, IsCVC("123", "amex"))
+	assert.True(t, IsCVC("123", "4111 1111 1111 1111"))
+	assert.False(t, IsCVC("1234", "4111111111111111"))
+	assert.True(t, IsCVC("1234", ""))
+
+	type Checkout struct {
+		CardNumber string `json:"cardNumber" valid:"required|creditcard"`
+		Expiry     string `json:"expiry" valid:"required|cardexpiry"`
+		CVC        string `json:"cvc" valid:"required|cvc(CardNumber)|name=Security code"`
+	}
+
+	bag, err := ValidateStruct(Checkout{CardNumber: "378282246310005", Expiry: "12/99", CVC: "1234"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"expiry:cardexpiry"}, embeddedErrorPaths(bag))
+
+	bag, err = ValidateStruct(Checkout{CardNumber: "4111111111111111", Expiry: "12/40", CVC: "1234"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"cvc:cvc"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "Security code must be a valid security code", bag.FirstErrorMessage())
+}
@@ -0,0 +1,256 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// EmailAddress is an address parsed by ParseEmail. Local is unquoted, Domain is lower case.
+type EmailAddress struct {
+	Local  string
+	Domain string
+}
+
+// String returns the address as it's written in mail headers. A local part that isn't a dot-atom, such as
+// "john doe", is quoted.
+func (a EmailAddress) String() string {
+	if isDotAtom(a.Local) {
+		return a.Local + "@" + a.Domain
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(a.Local) + `"@` + a.Domain
+}
+
+// isDotAtom is true if str is atoms joined by single dots (RFC 5322 section 3.2.3), so it doesn't need quotes.
+// Non-ASCII characters are allowed in atoms, as in RFC 6531.
+func isDotAtom(str string) bool {
+	if len(str) == 0 {
+		return false
+	}
+	for _, atom := range strings.Split(str, ".") {
+		if len(atom) == 0 {
+			return false
+		}
+		for _, r := range atom {
+			if r < utf8.RuneSelf && !isASCIIAlphanumeric(r) && !strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isASCIIAlphanumeric(r rune) bool {
+	return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9'
+}
+
+// RFC 5321 limits
+const (
+	maxEmailLocalLength  = 64
+	maxEmailDomainLength = 253
+	maxDNSLabelLength    = 63
+)
+
+// ParseEmail parses a bare RFC 5322 address (no display name or comments), such as john.doe@example.com or
+// "john doe"@example.com. The domain must be a host name with at least one dot; domain literals ([192.0.2.1]) are
+// rejected.
+func ParseEmail(str string) (EmailAddress, error) {
+	if len(str) == 0 || strings.TrimSpace(str) != str || strings.ContainsAny(str, "<>") {
+		return EmailAddress{}, fmt.Errorf("%q is not an email address", str)
+	}
+
+	addr, err := mail.ParseAddress(str)
+	if err != nil || len(addr.Name) > 0 {
+		return EmailAddress{}, fmt.Errorf("%q is not an email address", str)
+	}
+
+	idx := strings.LastIndex(addr.Address, "@")
+	a := EmailAddress{Local: addr.Address[:idx], Domain: strings.ToLower(addr.Address[idx+1:])}
+
+	// net/mail drops comments, which aren't allowed in a bare address
+	inputIdx := strings.LastIndex(str, "@")
+	if strings.ToLower(str[inputIdx+1:]) != a.Domain || (str[:inputIdx] != a.Local && !strings.HasPrefix(str, `"`)) {
+		return EmailAddress{}, fmt.Errorf("%q is not an email address", str)
+	}
+
+	if len(a.Local) > maxEmailLocalLength {
+		return EmailAddress{}, fmt.Errorf("The local part of %q is too long", str)
+	}
+	if err := checkEmailDomain(a.Domain); err != nil {
+		return EmailAddress{}, fmt.Errorf("%q is not an email address: %s", str, err.Error())
+	}
+
+	return a, nil
+}
+
+func checkEmailDomain(domain string) error {
+	if len(domain) > maxEmailDomainLength {
+		return errors.New("the domain is too long")
+	}
+
+	labels := strings.Split(strings.TrimSuffix(domain, "."), ".")
+	if len(labels) < 2 {
+		return errors.New("the domain must have a dot")
+	}
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > maxDNSLabelLength {
+			return fmt.Errorf("%q is not a valid domain label", label)
+		}
+		if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") || strings.HasPrefix(label, "[") {
+			return fmt.Errorf("%q is not a valid domain label", label)
+		}
+	}
+
+	return nil
+}
+
+// disposableDomains holds domains of throwaway mailbox providers. Subdomains are matched too.
+var disposableDomains = struct {
+	sync.RWMutex
+	domains map[string]bool
+}{
+	domains: map[string]bool{
+		"10minutemail.com":   true,
+		"dispostable.com":    true,
+		"fakeinbox.com":      true,
+		"getnada.com":        true,
+		"guerrillamail.com":  true,
+		"guerrillamail.net":  true,
+		"mailinator.com":     true,
+		"maildrop.cc":        true,
+		"mintemail.com":      true,
+		"sharklasers.com":    true,
+		"temp-mail.org":      true,
+		"tempmail.com":       true,
+		"throwawaymail.com":  true,
+		"trashmail.com":      true,
+		"yopmail.com":        true,
+		"mailnesia.com":      true,
+		"spamgourmet.com":    true,
+		"mohmal.com":         true,
+		"emailondeck.com":    true,
+		"burnermail.io":      true,
+		"tempr.email":        true,
+		"discard.email":      true,
+		"mytemp.email":       true,
+		"mailcatch.com":      true,
+		"incognitomail.org":  true,
+		"anonbox.net":        true,
+		"spambox.us":         true,
+		"tempinbox.com":      true,
+		"jetable.org":        true,
+		"mail-temporaire.fr": true,
+	},
+}
+
+// AddDisposableDomains adds domains to the disposable domain list used by IsDisposableEmail
+func AddDisposableDomains(domains ...string) {
+	disposableDomains.Lock()
+	defer disposableDomains.Unlock()
+	for _, domain := range domains {
+		disposableDomains.domains[strings.ToLower(strings.TrimSuffix(domain, "."))] = true
+	}
+}
+
+// IsDisposableEmail is true if the address is at a disposable domain, or a subdomain of one. Invalid addresses are
+// not disposable.
+func IsDisposableEmail(str string) bool {
+	a, err := ParseEmail(str)
+	if err != nil {
+		return false
+	}
+
+	disposableDomains.RLock()
+	defer disposableDomains.RUnlock()
+
+	domain := strings.TrimSuffix(a.Domain, ".")
+	for {
+		if disposableDomains.domains[domain] {
+			return true
+		}
+		idx := strings.Index(domain, ".")
+		if idx < 0 {
+			return false
+		}
+		domain = domain[idx+1:]
+	}
+}
+
+// IsNotDisposableEmail is the nodisposable validator. Empty values are ignored; use required for them.
+func IsNotDisposableEmail(str string, params ...interface{}) bool {
+	return len(str) == 0 || !IsDisposableEmail(str)
+}
+
+// Resolver looks up the DNS records used to check email domains. *net.Resolver implements it.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+var resolver = struct {
+	sync.RWMutex
+	r Resolver
+}{r: net.DefaultResolver}
+
+// SetResolver sets the resolver used by the mx validator
+func SetResolver(r Resolver) {
+	resolver.Lock()
+	defer resolver.Unlock()
+	resolver.r = r
+}
+
+func currentResolver() Resolver {
+	resolver.RLock()
+	defer resolver.RUnlock()
+	return resolver.r
+}
+
+// HasMXRecord checks that the domain of an email address can receive email: it has MX records, or no MX records
+// but an address record (the implicit MX of RFC 5321). Domains with a null MX (RFC 7505) can't receive email.
+// Missing domains are reported as false; other DNS failures are returned as errors.
+func HasMXRecord(ctx context.Context, str string) (bool, error) {
+	a, err := ParseEmail(str)
+	if err != nil {
+		return false, nil
+	}
+
+	r := currentResolver()
+	records, err := r.LookupMX(ctx, a.Domain)
+	if err != nil && !isNotFound(err) {
+		return false, err
+	}
+	if len(records) == 1 && (records[0].Host == "." || records[0].Host == "") {
+		return false, nil
+	}
+	if len(records) > 0 {
+		return true, nil
+	}
+
+	hosts, err := r.LookupHost(ctx, a.Domain)
+	if err != nil && !isNotFound(err) {
+		return false, err
+	}
+	return len(hosts) > 0, nil
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// isMXEmail is the mx validator. Empty values are ignored; use required for them.
+func isMXEmail(ctx context.Context, val interface{}, params ...interface{}) (bool, error) {
+	str, ok := val.(string)
+	if !ok {
+		return false, fmt.Errorf("mx only validates strings; got %T", val)
+	}
+	if len(str) == 0 {
+		return true, nil
+	}
+	return HasMXRecord(ctx, str)
+}
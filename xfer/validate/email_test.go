@@ -0,0 +1,159 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+)
+
+// fakeResolver serves DNS records from memory
+type fakeResolver struct {
+	mx    map[string][]*net.MX
+	hosts map[string][]string
+}
+
+func (r fakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	if name == "timeout.example" {
+		return nil, &net.DNSError{Err: "i/o timeout", Name: name, IsTimeout: true}
+	}
+	if records, ok := r.mx[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if hosts, ok := r.hosts[host]; ok {
+		return hosts, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestParseEmail(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		expected string
+	}{
+		{"", ""},
+		{"foo@bar.com", "foo@bar.com"},
+		{"x@x.x", "x@x.x"},
+		{"foo+bar@bar.com", "foo+bar@bar.com"},
+		{"foo@bar.中文网", "foo@bar.中文网"},
+		{"test|123@m端ller.com", "test|123@m端ller.com"},
+		{"NathAn.daVIeS@DomaIn.cOM", "NathAn.daVIeS@domain.com"},
+		{`"john doe"@example.com`, `"john doe"@example.com`},
+		{"invalidemail@", ""},
+		{"invalid.com", ""},
+		{"@invalid.com", ""},
+		{"a..b@example.com", ""},
+		{"john@localhost", ""},
+		{"john@[192.0.2.1]", ""},
+		{"john@-example.com", ""},
+		{"John <john@example.com>", ""},
+		{"john@example.com (John)", ""},
+		{" john@example.com", ""},
+		{"a1234567890123456789012345678901234567890123456789012345678901234@example.com", ""},
+	}
+	for _, test := range tests {
+		a, err := ParseEmail(test.param)
+		if len(test.expected) == 0 {
+			assert.NotNil(t, err, test.param)
+			assert.False(t, IsEmail(test.param), test.param)
+			continue
+		}
+		assert.Nil(t, err, test.param)
+		assert.Equal(t, test.expected, a.String())
+		assert.True(t, IsEmail(test.param), test.param)
+	}
+}
+
+func TestIsDisposableEmail(t *testing.T) {
+	t.Parallel()
+
+	AddDisposableDomains("Throwaway.Example.")
+
+	assert.True(t, IsDisposableEmail("john@mailinator.com"))
+	assert.True(t, IsDisposableEmail("john@eu.MAILINATOR.com"))
+	assert.True(t, IsDisposableEmail("john@throwaway.example"))
+	assert.False(t, IsDisposableEmail("john@notmailinator.com"))
+	assert.False(t, IsDisposableEmail("john@example.com"))
+	assert.False(t, IsDisposableEmail("mailinator.com"))
+
+	type Signup struct {
+		Email string `json:"email" valid:"email|nodisposable"`
+	}
+	bag, err := ValidateStruct(Signup{Email: "john@yopmail.com"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Email must not be a disposable address", bag.FirstErrorMessage())
+}
+
+func TestMXRecords(t *testing.T) {
+	SetResolver(fakeResolver{
+		mx: map[string][]*net.MX{
+			"example.com":    {{Host: "mx1.example.com.", Pref: 10}},
+			"nullmx.example": {{Host: ".", Pref: 0}},
+		},
+		hosts: map[string][]string{"implicit.example": {"192.0.2.1"}},
+	})
+	defer SetResolver(net.DefaultResolver)
+
+	ctx := context.Background()
+	for email, expected := range map[string]bool{
+		"john@example.com":      true,
+		"john@implicit.example": true,
+		"john@nullmx.example":   false,
+		"john@missing.example":  false,
+		"not an email":          false,
+	} {
+		valid, err := HasMXRecord(ctx, email)
+		assert.Nil(t, err, email)
+		assert.Equal(t, expected, valid, email)
+	}
+
+	_, err := HasMXRecord(ctx, "john@timeout.example")
+	var dnsErr *net.DNSError
+	assert.True(t, errors.As(err, &dnsErr))
+
+	type Signup struct {
+		Email  string `json:"email" valid:"required|email|mx"`
+		Backup string `json:"backup" valid:"mx"`
+	}
+	bag, err := ValidateStruct(Signup{Email: "john@missing.example"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"email:mx"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "Email must be an address that can receive email", bag.FirstErrorMessage())
+
+	_, err = ValidateStruct(Signup{Email: "john@timeout.example"})
+	assert.NotNil(t, err)
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		expected string
+	}{
+		{`test@me.com`, `test@me.com`},
+		{`some.name@gmail.com`, `somename@gmail.com`},
+		{`some.name@googleMail.com`, `somename@gmail.com`},
+		{`some.name+extension@gmail.com`, `somename@gmail.com`},
+		{`some.Name+extension@GoogleMail.com`, `somename@gmail.com`},
+		{`some.Name@example.COM`, `some.Name@example.com`},
+		{`"John Doe"@example.com`, `"John Doe"@example.com`},
+		{`"john\"doe"@Example.com`, `"john\"doe"@example.com`},
+		{`"john.doe"@example.com`, `john.doe@example.com`},
+	}
+	for _, test := range tests {
+		actual, err := NormalizeEmail(test.param)
+		assert.Nil(t, err, test.param)
+		assert.Equal(t, test.expected, actual)
+	}
+
+	_, err := NormalizeEmail("not an email")
+	assert.NotNil(t, err)
+}
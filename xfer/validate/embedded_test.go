@@ -0,0 +1,84 @@
+package validate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type BaseForm struct {
+	Email string `json:"email" valid:"required|email"`
+	Name  string `json:"name" valid:"required"`
+}
+
+type auditFields struct {
+	Reason string `json:"reason" valid:"required"`
+}
+
+func embeddedErrorPaths(bag *ErrorBag) []string {
+	paths := []string{}
+	for _, e := range bag.Errors() {
+		paths = append(paths, e.Path+":"+e.Validator)
+	}
+	return paths
+}
+
+func TestEmbeddedStructFieldsArePromoted(t *testing.T) {
+	t.Parallel()
+
+	type SignupForm struct {
+		BaseForm
+		*auditFields
+		Terms string `json:"terms" valid:"required"`
+	}
+
+	bag, err := ValidateStruct(SignupForm{BaseForm: BaseForm{Email: "nope"}, auditFields: &auditFields{}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"email:email", "name:required", "reason:required", "terms:required"}, embeddedErrorPaths(bag))
+
+	// nil embedded pointers are skipped
+	bag, err = ValidateStruct(SignupForm{BaseForm: BaseForm{Email: "a@b.com", Name: "A"}, Terms: "accepted"})
+	assert.Nil(t, err)
+	assert.False(t, bag.HasErrors())
+}
+
+func TestEmbeddedStructCanBeIgnored(t *testing.T) {
+	t.Parallel()
+
+	type AdminForm struct {
+		BaseForm `valid:"-"`
+		Role     string `json:"role" valid:"required"`
+	}
+
+	bag, err := ValidateStruct(AdminForm{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"role:required"}, embeddedErrorPaths(bag))
+}
+
+func TestOuterFieldOverridesEmbeddedRules(t *testing.T) {
+	t.Parallel()
+
+	type GuestForm struct {
+		BaseForm
+		Email string `json:"email" valid:"lowercase"` // optional for guests
+	}
+
+	bag, err := ValidateStruct(GuestForm{BaseForm: BaseForm{Name: "A"}})
+	assert.Nil(t, err)
+	assert.False(t, bag.HasErrors())
+
+	bag, err = ValidateStruct(GuestForm{Email: "NOPE"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"name:required", "email:lowercase"}, embeddedErrorPaths(bag))
+}
+
+func TestNamedEmbeddedStructIsNotPromoted(t *testing.T) {
+	t.Parallel()
+
+	type ProfileForm struct {
+		BaseForm `json:"base"`
+	}
+
+	bag, err := ValidateStruct(ProfileForm{BaseForm: BaseForm{Email: "a@b.com"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"base.name:required"}, embeddedErrorPaths(bag))
+}
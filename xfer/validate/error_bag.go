@@ -2,10 +2,20 @@ package validate
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/ansel1/merry"
+	"sort"
+	"strings"
 )
 
+// ErrValidation matches any validation Error or ErrorBag with errors.Is
+var ErrValidation = errors.New("validation failed")
+
+// RedactedValue replaces the rejected value of fields with the redact=true setting
+const RedactedValue = `[redacted]`
+
 /*
 TODO Validation upgrades from asaskevich package:
 
@@ -13,7 +23,6 @@ TODO Validation upgrades from asaskevich package:
 - To preserve generic error interface, offer different validation method that returns that
 - add support for db-linked validations: exists, unique
 - add other laravel validations: in,not in, before/after, active URL
-- file validations? dimensions, image
 - bigger variety of built-in char set validations (name, title)
 - conditional validations?
 - nicer array validations for simple methods
@@ -25,6 +34,39 @@ type Error struct {
 	Name  string
 	Field string
 	Err   error
+
+	// Path is the field's path from the validated struct, e.g. shipping.lines[2].sku
+	Path string
+
+	// Validator is the key of the validator that failed (required, email...)
+	Validator string
+
+	// Params holds the validator params, keyed by name (min, max) or by index for validators without param names
+	Params map[string]interface{}
+
+	// Value is the rejected value, or RedactedValue for fields with the redact=true setting. It's left out of the
+	// JSON of the error unless it's marshalled through ErrorBag.ProblemWithValues.
+	Value    interface{}
+	Redacted bool
+
+	// Reasons are the keys of the reasons the value failed, for validators that explain failures (password)
+	Reasons []string
+
+	// MessageKey is kept so the message can be rendered again in another locale (see Localize). It's empty for
+	// custom messages, which are never translated.
+	MessageKey string
+
+	// values holds the message placeholder values, including the ones that aren't kept in the error (other fields)
+	values map[string]interface{}
+
+	// label resolves Field in other locales
+	label fieldLabel
+
+	// reasons are rendered again into the {reasons} placeholder in other locales
+	reasons []Reason
+
+	// marshalValue includes Value in the JSON of the error
+	marshalValue bool
 }
 
 func (e Error) Error() string {
@@ -34,34 +76,187 @@ func (e Error) Error() string {
 	return e.Name + ": " + e.Err.Error()
 }
 
+// Unwrap returns the error holding the message, so errors.Is and errors.As can reach it
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrValidation
+func (e Error) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// withPrefix returns a copy of the error with prefix added to its key and path
+func (e Error) withPrefix(prefix string) Error {
+	e.Name = joinPath(prefix, e.Name)
+	if strings.HasPrefix(e.Path, "[") {
+		e.Path = prefix + e.Path
+	} else {
+		e.Path = joinPath(prefix, e.Path)
+	}
+	return e
+}
+
+// Localize returns a copy of the error with its message rendered in the given locale
+func (e Error) Localize(locale string) Error {
+	if len(e.label.field.Name) > 0 {
+		e.Field = e.label.in(locale)
+	}
+	if len(e.MessageKey) == 0 {
+		return e
+	}
+
+	values := make(map[string]interface{}, len(e.values)+2)
+	for name, value := range e.values {
+		values[name] = value
+	}
+	if e.values == nil {
+		values = messageValues(e.Field, e.Value, e.Params)
+	}
+	values["field"] = e.Field
+	if len(e.reasons) > 0 {
+		values[reasonsPlaceholder] = renderReasons(e.Validator, e.reasons, locale)
+	}
+
+	if msg, found := localizedMessage(locale, e.MessageKey, false, values); found {
+		e.Err = errors.New(msg)
+	}
+	return e
+}
+
+// Message returns the error message without the name prefix
+func (e Error) Message() string {
+	if e.Err == nil {
+		return ``
+	}
+	return e.Err.Error()
+}
+
+// jsonError is the JSON representation of an Error
+type jsonError struct {
+	Name       string                 `json:"name,omitempty"`
+	Field      string                 `json:"field,omitempty"`
+	Path       string                 `json:"path,omitempty"`
+	Validator  string                 `json:"validator,omitempty"`
+	Params     map[string]interface{} `json:"params,omitempty"`
+	Value      interface{}            `json:"value,omitempty"`
+	Reasons    []string               `json:"reasons,omitempty"`
+	Message    string                 `json:"message"`
+	MessageKey string                 `json:"message_key,omitempty"`
+}
+
+// MarshalJSON marshals the error without the rejected value, which may be personal data the client shouldn't get
+// back in a response or that shouldn't end up in logs
+func (e Error) MarshalJSON() ([]byte, error) {
+	je := jsonError{
+		Name:       e.Name,
+		Field:      e.Field,
+		Path:       e.Path,
+		Validator:  e.Validator,
+		Params:     e.Params,
+		Reasons:    e.Reasons,
+		Message:    e.Message(),
+		MessageKey: e.MessageKey,
+	}
+	if e.marshalValue {
+		je.Value = e.Value
+	}
+	return json.Marshal(je)
+}
+
 type ErrorBag struct {
 	errors map[string][]Error
+
+	// ordered holds the same errors as the map, in the order they were added (struct field order during validation)
+	ordered []Error
+
+	// locale used to render messages of errors added during validation
+	locale string
+
+	// pending holds the slow validators queued during validation (see runSlowChecks)
+	pending []slowCheck
 }
 
 func NewErrorBag() *ErrorBag {
 
 	eb := make(map[string][]Error)
 
-	return &ErrorBag{eb}
+	return &ErrorBag{errors: eb, locale: currentMessagesLocale()}
 }
 
-func (eb *ErrorBag) Errors() []Error {
+// newLocalizedErrorBag creates an error bag that renders validation messages in locale
+func newLocalizedErrorBag(locale string) *ErrorBag {
+	eb := NewErrorBag()
+	eb.locale = locale
+	return eb
+}
 
-	es := make([]Error, 0)
+// Locale returns the locale the bag's messages are rendered in
+func (eb *ErrorBag) Locale() string {
+	return eb.locale
+}
 
-	for _, v := range (*eb).errors {
-		es = append(es, v...)
+// Localize returns a copy of the bag with every message rendered in the given locale
+func (eb *ErrorBag) Localize(locale string) *ErrorBag {
+	localized := newLocalizedErrorBag(normalizeLocale(locale))
+	for _, e := range eb.ordered {
+		localized.addError(e.Localize(localized.locale))
 	}
+	return localized
+}
+
+// Errors returns all errors in the order they were added, which is struct field order for validation errors
+func (eb *ErrorBag) Errors() []Error {
+
+	es := make([]Error, len(eb.ordered))
+	copy(es, eb.ordered)
 
 	return es
 }
 
+// Sorted returns all errors sorted by key. Errors with the same key keep the order they were added in.
+func (eb *ErrorBag) Sorted() []Error {
+	es := eb.Errors()
+	sort.SliceStable(es, func(i, j int) bool {
+		return es[i].Name < es[j].Name
+	})
+	return es
+}
+
+// Keys returns the error keys in the order they were first added
+func (eb *ErrorBag) Keys() []string {
+	keys := make([]string, 0, len(eb.errors))
+	seen := make(map[string]bool, len(eb.errors))
+	for _, e := range eb.ordered {
+		if !seen[e.Name] {
+			seen[e.Name] = true
+			keys = append(keys, e.Name)
+		}
+	}
+	return keys
+}
+
+// First returns the first error added for key
+func (eb *ErrorBag) First(key string) (Error, bool) {
+	if errs := eb.errors[key]; len(errs) > 0 {
+		return errs[0], true
+	}
+	return Error{}, false
+}
+
+// FirstErrorMessage returns the message of the first error in the bag, or an empty string if there are no errors.
+// It's meant for UIs that only have room for a single message.
+func (eb *ErrorBag) FirstErrorMessage() string {
+	if len(eb.ordered) == 0 {
+		return ``
+	}
+	return eb.ordered[0].Message()
+}
+
 func (eb *ErrorBag) Error() string {
 	var err string
-	for _, slc := range (*eb).errors {
-		for _, e := range slc {
-			err += e.Error() + ";"
-		}
+	for _, e := range eb.ordered {
+		err += e.Error() + ";"
 	}
 	return err
 }
@@ -88,6 +283,7 @@ func (eb *ErrorBag) addError(err Error) *ErrorBag {
 	} else {
 		(*eb).errors[err.Name] = append(slc, err)
 	}
+	eb.ordered = append(eb.ordered, err)
 
 	return eb
 }
@@ -98,21 +294,121 @@ func (eb *ErrorBag) HasErrors() bool {
 
 func (eb *ErrorBag) String() string {
 	res := new(bytes.Buffer)
-	for _, v := range eb.errors {
-		for _, e := range v {
-			if res.Len() > 0 {
-				_, _ = res.WriteString("; ")
-			}
-			if e.Field == "" {
-				_, _ = res.WriteString(fmt.Sprintf("[%s] %s", e.Field, e.Error()))
-			} else {
-				_, _ = res.WriteString(e.Error())
-			}
+	for _, e := range eb.ordered {
+		if res.Len() > 0 {
+			_, _ = res.WriteString("; ")
+		}
+		if e.Field == "" {
+			_, _ = res.WriteString(fmt.Sprintf("[%s] %s", e.Field, e.Error()))
+		} else {
+			_, _ = res.WriteString(e.Error())
 		}
 	}
 	return res.String()
 }
 
+// Merge adds all errors of other to the bag, after its own errors
+func (eb *ErrorBag) Merge(other *ErrorBag) *ErrorBag {
+	if other == nil {
+		return eb
+	}
+	for _, e := range other.ordered {
+		eb.addError(e)
+	}
+	return eb
+}
+
+// WithPrefix returns a copy of the bag with prefix added to every key and path, to nest the bag of a sub form or
+// service under a field. An address bag with a "city" key becomes "shipping.city" with the prefix "shipping".
+func (eb *ErrorBag) WithPrefix(prefix string) *ErrorBag {
+	prefixed := newLocalizedErrorBag(eb.locale)
+	for _, e := range eb.ordered {
+		prefixed.addError(e.withPrefix(prefix))
+	}
+	return prefixed
+}
+
+// Filter returns a copy of the bag with only the errors for which keep returns true
+func (eb *ErrorBag) Filter(keep func(e Error) bool) *ErrorBag {
+	filtered := newLocalizedErrorBag(eb.locale)
+	for _, e := range eb.ordered {
+		if keep(e) {
+			filtered.addError(e)
+		}
+	}
+	return filtered
+}
+
+// Remove removes all errors for key from the bag
+func (eb *ErrorBag) Remove(key string) *ErrorBag {
+	if _, ok := eb.errors[key]; !ok {
+		return eb
+	}
+
+	delete(eb.errors, key)
+	ordered := eb.ordered[:0]
+	for _, e := range eb.ordered {
+		if e.Name != key {
+			ordered = append(ordered, e)
+		}
+	}
+	eb.ordered = ordered
+
+	return eb
+}
+
+// Unwrap returns the errors in the bag, so errors.As can extract a single Error from the bag, or from errors.Join
+// of several bags
+func (eb *ErrorBag) Unwrap() []error {
+	errs := make([]error, len(eb.ordered))
+	for i, e := range eb.ordered {
+		errs[i] = e
+	}
+	return errs
+}
+
+// Is reports whether target is ErrValidation and the bag has errors
+func (eb *ErrorBag) Is(target error) bool {
+	return target == ErrValidation && eb.HasErrors()
+}
+
+// Merry wraps the bag in a merry error carrying a stack trace, the 400 HTTP code and the first error message as the
+// user message. Returns nil if the bag has no errors.
+func (eb *ErrorBag) Merry() merry.Error {
+	if !eb.HasErrors() {
+		return nil
+	}
+	return merry.WrapSkipping(eb, 1).WithHTTPCode(ProblemStatus).WithUserMessage(eb.FirstErrorMessage())
+}
+
+// ErrorBagFromError collects the validation errors found in err into a single bag. err can be an ErrorBag, an
+// Error, or any error wrapping them, including errors.Join and merry errors. Returns false if err holds no
+// validation errors.
+func ErrorBagFromError(err error) (*ErrorBag, bool) {
+	bag := NewErrorBag()
+	collectErrors(err, bag)
+	return bag, bag.HasErrors()
+}
+
+func collectErrors(err error, bag *ErrorBag) {
+	switch e := err.(type) {
+	case nil:
+		return
+	case *ErrorBag:
+		bag.Merge(e)
+		return
+	case Error:
+		bag.addError(e)
+		return
+	case interface{ Unwrap() []error }:
+		for _, wrapped := range e.Unwrap() {
+			collectErrors(wrapped, bag)
+		}
+	default:
+		collectErrors(errors.Unwrap(err), bag)
+	}
+}
+
 func (eb *ErrorBag) HasErrorFor(key string) bool {
 	_, ok := (*eb).errors[key]
 	return ok
@@ -0,0 +1,233 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ansel1/merry"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type structuredAddress struct {
+	City string `json:"city" valid:"required"`
+}
+
+type structuredSignup struct {
+	Email    string              `json:"email" valid:"email"`
+	Password string              `json:"password" valid:"between(8,64)|redact=true"`
+	Address  structuredAddress   `json:"address"`
+	Previous []structuredAddress `json:"previous"`
+}
+
+func TestStructuredErrors(t *testing.T) {
+	t.Parallel()
+
+	bag, err := ValidateStruct(structuredSignup{
+		Email:    "nope",
+		Password: "hunter2",
+		Previous: []structuredAddress{{City: "Paris"}, {}},
+	})
+	assert.Nil(t, err)
+
+	byPath := map[string]Error{}
+	for _, e := range bag.Errors() {
+		byPath[e.Path] = e
+	}
+	assert.Equal(t, 4, len(byPath))
+
+	email := byPath["email"]
+	assert.Equal(t, "email", email.Validator)
+	assert.Equal(t, "email", email.MessageKey)
+	assert.Equal(t, "nope", email.Value)
+	assert.Equal(t, "Email must be a valid address", email.Message())
+
+	password := byPath["password"]
+	assert.Equal(t, "between", password.Validator)
+	assert.Equal(t, map[string]interface{}{"min": "8", "max": "64"}, password.Params)
+	assert.Equal(t, RedactedValue, password.Value)
+	assert.True(t, password.Redacted)
+
+	assert.Equal(t, "required", byPath["address.city"].Validator)
+	assert.Equal(t, "required", byPath["previous[1].city"].Validator)
+}
+
+func TestRedactedValueIsNotInMessages(t *testing.T) {
+	t.Parallel()
+
+	type Login struct {
+		Password string `valid:"alpha->{value} is not a valid password|redact=true"`
+	}
+
+	bag, err := ValidateStruct(Login{Password: "s3cr3t!"})
+	assert.Nil(t, err)
+	assert.Equal(t, RedactedValue+" is not a valid password", bag.Errors()[0].Message())
+}
+
+func TestInvalidRedactSetting(t *testing.T) {
+	t.Parallel()
+
+	type Login struct {
+		Password string `valid:"alpha|redact=maybe"`
+	}
+
+	_, err := ValidateStruct(Login{Password: "x"})
+	assert.NotNil(t, err)
+}
+
+func TestErrorBagProblemJSON(t *testing.T) {
+	t.Parallel()
+
+	bag, err := ValidateStruct(structuredSignup{Email: "test@example.com", Password: "short", Address: structuredAddress{City: "Rome"}})
+	assert.Nil(t, err)
+
+	body, err := json.Marshal(bag)
+	assert.Nil(t, err)
+
+	var problem map[string]interface{}
+	assert.Nil(t, json.Unmarshal(body, &problem))
+	assert.Equal(t, "about:blank", problem["type"])
+	assert.Equal(t, float64(400), problem["status"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"field":       "Password",
+			"name":        "password",
+			"path":        "password",
+			"validator":   "between",
+			"params":      map[string]interface{}{"min": "8", "max": "64"},
+			"message":     "Password must be between 8 and 64",
+			"message_key": "between",
+		},
+	}, problem["errors"])
+}
+
+func TestErrorBagProblemValues(t *testing.T) {
+	t.Parallel()
+
+	bag, err := ValidateStruct(structuredSignup{Email: "ann@example", Password: "short", Address: structuredAddress{City: "Rome"}})
+	assert.Nil(t, err)
+
+	body, err := json.Marshal(bag)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(body), "ann@example")
+	assert.NotContains(t, string(body), `"value"`)
+
+	body, err = json.Marshal(bag.Errors()[0])
+	assert.Nil(t, err)
+	assert.NotContains(t, string(body), "ann@example")
+
+	body, err = json.Marshal(bag.ProblemWithValues())
+	assert.Nil(t, err)
+	assert.Contains(t, string(body), `"value":"ann@example"`)
+	assert.Contains(t, string(body), `"value":"`+RedactedValue+`"`)
+	assert.NotContains(t, string(body), "short")
+}
+
+func TestErrorBagOrdering(t *testing.T) {
+	t.Parallel()
+
+	type Form struct {
+		Zeta  string `valid:"required"`
+		Alpha string `json:"alpha" valid:"required"`
+		Mid   string `valid:"required|email"`
+		Beta  string `json:"beta" valid:"required"`
+	}
+
+	for i := 0; i < 20; i++ {
+		bag, err := ValidateStruct(Form{Mid: "x"})
+		assert.Nil(t, err)
+
+		paths := []string{}
+		for _, e := range bag.Errors() {
+			paths = append(paths, e.Path)
+		}
+		assert.Equal(t, []string{"Zeta", "alpha", "Mid", "beta"}, paths)
+		assert.Equal(t, []string{"Zeta", "alpha", "Mid", "beta"}, bag.Keys())
+		assert.Equal(t, "Zeta: Zeta must not be empty;alpha: Alpha must not be empty;Mid: Mid must be a valid address;beta: Beta must not be empty;", bag.Error())
+		assert.Equal(t, "Zeta must not be empty", bag.FirstErrorMessage())
+
+		sorted := []string{}
+		for _, e := range bag.Sorted() {
+			sorted = append(sorted, e.Path)
+		}
+		assert.Equal(t, []string{"Mid", "Zeta", "alpha", "beta"}, sorted)
+	}
+}
+
+func TestErrorBagFirst(t *testing.T) {
+	t.Parallel()
+
+	bag := NewErrorBag().Add("email", "first", "Email").Add("email", "second", "Email")
+
+	first, found := bag.First("email")
+	assert.True(t, found)
+	assert.Equal(t, "first", first.Message())
+
+	_, found = bag.First("name")
+	assert.False(t, found)
+	assert.Equal(t, "", NewErrorBag().FirstErrorMessage())
+}
+
+func TestErrorBagMergeWithPrefix(t *testing.T) {
+	t.Parallel()
+
+	bag, err := ValidateStruct(structuredSignup{Email: "nope", Password: "long enough", Address: structuredAddress{City: "Oslo"}})
+	assert.Nil(t, err)
+
+	shipping, err := ValidateStruct(structuredAddress{})
+	assert.Nil(t, err)
+
+	bag.Merge(shipping.WithPrefix("shipping"))
+	assert.Equal(t, []string{"email", "shipping.city"}, bag.Keys())
+
+	city, found := bag.First("shipping.city")
+	assert.True(t, found)
+	assert.Equal(t, "shipping.city", city.Path)
+
+	// the merged bag is a copy
+	assert.Equal(t, []string{"city"}, shipping.Keys())
+}
+
+func TestErrorBagFilterRemove(t *testing.T) {
+	t.Parallel()
+
+	bag := NewErrorBag().Add("email", "bad email", "Email").Add("name", "bad name", "Name").Add("email", "taken", "Email")
+
+	emails := bag.Filter(func(e Error) bool { return e.Name == "email" })
+	assert.Equal(t, 2, len(emails.Errors()))
+	assert.Equal(t, 3, len(bag.Errors()))
+
+	bag.Remove("email")
+	assert.False(t, bag.HasErrorFor("email"))
+	assert.Equal(t, []string{"name"}, bag.Keys())
+	assert.Equal(t, "name: bad name;", bag.Error())
+}
+
+func TestErrorBagErrorsInterop(t *testing.T) {
+	t.Parallel()
+
+	billing := NewErrorBag().Add("iban", "invalid IBAN", "IBAN")
+	shipping := NewErrorBag().Add("city", "required", "City").WithPrefix("shipping")
+
+	joined := errors.Join(fmt.Errorf("billing: %w", billing), shipping)
+	assert.True(t, errors.Is(joined, ErrValidation))
+	assert.False(t, errors.Is(NewErrorBag(), ErrValidation))
+
+	var first Error
+	assert.True(t, errors.As(joined, &first))
+	assert.Equal(t, "iban", first.Name)
+
+	var bag *ErrorBag
+	assert.True(t, errors.As(joined, &bag))
+	assert.Equal(t, billing, bag)
+
+	collected, found := ErrorBagFromError(merry.Wrap(joined))
+	assert.True(t, found)
+	assert.Equal(t, []string{"iban", "shipping.city"}, collected.Keys())
+
+	_, found = ErrorBagFromError(errors.New("boom"))
+	assert.False(t, found)
+
+	assert.Nil(t, NewErrorBag().Merry())
+	assert.Equal(t, "invalid IBAN", merry.UserMessage(billing.Merry()))
+}
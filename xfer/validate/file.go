@@ -0,0 +1,221 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	// decoders for the dimensions and ratio rules
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// sniffLength is the number of bytes http.DetectContentType looks at
+const sniffLength = 512
+
+// ratioTolerance is how far, relative to the expected ratio, an image ratio can be off, so a 1921x1080 image is
+// still 16/9
+const ratioTolerance = 0.01
+
+var fileHeaderType = reflect.TypeOf(multipart.FileHeader{})
+
+// sizeUnits are the units accepted by maxsize, as powers of 1024
+var sizeUnits = map[string]int64{
+	"":    1,
+	"B":   1,
+	"KB":  1 << 10,
+	"KIB": 1 << 10,
+	"MB":  1 << 20,
+	"MIB": 1 << 20,
+	"GB":  1 << 30,
+	"GIB": 1 << 30,
+}
+
+// isFileType is true for the types validated as uploaded files, multipart.FileHeader and pointers to it. Their
+// fields aren't validated one by one.
+func isFileType(ty reflect.Type) bool {
+	if ty.Kind() == reflect.Ptr {
+		ty = ty.Elem()
+	}
+	return ty == fileHeaderType
+}
+
+// toFile returns the uploaded file validated by a file rule, or nil if there's none
+func toFile(key string, val interface{}) (*multipart.FileHeader, error) {
+	switch f := val.(type) {
+	case *multipart.FileHeader:
+		return f, nil
+	case multipart.FileHeader:
+		return &f, nil
+	}
+	return nil, fmt.Errorf("%s only validates *multipart.FileHeader; got %T", key, val)
+}
+
+// ParseSize parses a size such as 512, 200KB or 5MB into bytes. Units are powers of 1024 and ignore case.
+func ParseSize(str string) (int64, error) {
+	str = strings.ToUpper(strings.TrimSpace(str))
+	idx := strings.IndexFunc(str, func(c rune) bool { return c < '0' || c > '9' })
+	if idx < 0 {
+		idx = len(str)
+	}
+
+	n, err := strconv.ParseInt(str[:idx], 10, 64)
+	unit, ok := sizeUnits[strings.TrimSpace(str[idx:])]
+	if err != nil || !ok || n > math.MaxInt64/unit {
+		return 0, fmt.Errorf("%q is not a valid size", str)
+	}
+	return n * unit, nil
+}
+
+// isMaxSize is the maxsize(5MB) validator. Missing files are valid; use required for them.
+func isMaxSize(ctx context.Context, val interface{}, params ...interface{}) (bool, error) {
+	f, err := toFile("maxsize", val)
+	if err != nil || f == nil {
+		return f == nil && err == nil, err
+	}
+	if len(params) != 1 {
+		return false, fmt.Errorf("maxsize needs a size, e.g. maxsize(5MB)")
+	}
+
+	max, err := ParseSize(toString(params[0]))
+	if err != nil {
+		return false, err
+	}
+	return f.Size <= max, nil
+}
+
+// DetectFileType returns the MIME type of an uploaded file, sniffed from its content with http.DetectContentType.
+// The file name and the Content-Type sent by the client are ignored, as they can't be trusted.
+func DetectFileType(f *multipart.FileHeader) (string, error) {
+	file, err := f.Open()
+	if err != nil {
+		return ``, err
+	}
+	defer file.Close()
+
+	buf := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return ``, err
+	}
+
+	mimeType := http.DetectContentType(buf[:n])
+	return strings.TrimSpace(strings.Split(mimeType, ";")[0]), nil
+}
+
+// isMimes is the mimes(image/png,image/jpeg) validator. Types can end with a wildcard, e.g. image/*. Missing files
+// are valid; use required for them.
+func isMimes(ctx context.Context, val interface{}, params ...interface{}) (bool, error) {
+	f, err := toFile("mimes", val)
+	if err != nil || f == nil {
+		return f == nil && err == nil, err
+	}
+
+	mimeType, err := DetectFileType(f)
+	if err != nil {
+		return false, err
+	}
+	for _, param := range params {
+		allowed := strings.ToLower(strings.TrimSpace(toString(param)))
+		if allowed == mimeType || (strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mimeType, allowed[:len(allowed)-1])) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// imageConfig decodes the header of an uploaded image. ok is false if the file isn't an image in a known format.
+func imageConfig(f *multipart.FileHeader) (config image.Config, ok bool, err error) {
+	file, err := f.Open()
+	if err != nil {
+		return config, false, err
+	}
+	defer file.Close()
+
+	config, _, err = image.DecodeConfig(file)
+	return config, err == nil, nil
+}
+
+// isDimensions is the dimensions(minw,minh,maxw,maxh) validator. Bounds that are missing, empty or 0 aren't
+// checked, e.g. dimensions(100,100) only sets a minimum. Files that aren't images are invalid; missing files are
+// valid.
+func isDimensions(ctx context.Context, val interface{}, params ...interface{}) (bool, error) {
+	f, err := toFile("dimensions", val)
+	if err != nil || f == nil {
+		return f == nil && err == nil, err
+	}
+
+	bounds := make([]int, 4)
+	for i, param := range params {
+		str := strings.TrimSpace(toString(param))
+		if i >= len(bounds) || len(str) == 0 {
+			continue
+		}
+		if bounds[i], err = strconv.Atoi(str); err != nil || bounds[i] < 0 {
+			return false, fmt.Errorf("Invalid dimensions param %q", str)
+		}
+	}
+
+	config, ok, err := imageConfig(f)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	minw, minh, maxw, maxh := bounds[0], bounds[1], bounds[2], bounds[3]
+	return config.Width >= minw && config.Height >= minh &&
+		(maxw == 0 || config.Width <= maxw) && (maxh == 0 || config.Height <= maxh), nil
+}
+
+// parseRatio parses a ratio given as width/height (16/9) or as a number (1.5)
+func parseRatio(str string) (float64, error) {
+	str = strings.TrimSpace(str)
+	parts := strings.Split(str, "/")
+	if len(parts) > 2 {
+		return 0, fmt.Errorf("%q is not a valid ratio", str)
+	}
+
+	ratio, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err == nil && len(parts) == 2 {
+		var height float64
+		height, err = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if height == 0 {
+			return 0, fmt.Errorf("%q is not a valid ratio", str)
+		}
+		ratio /= height
+	}
+	if err != nil || ratio <= 0 || math.IsInf(ratio, 0) || math.IsNaN(ratio) {
+		return 0, fmt.Errorf("%q is not a valid ratio", str)
+	}
+	return ratio, nil
+}
+
+// isRatio is the ratio(16/9) validator, checking the width to height ratio of images. Files that aren't images are
+// invalid; missing files are valid.
+func isRatio(ctx context.Context, val interface{}, params ...interface{}) (bool, error) {
+	f, err := toFile("ratio", val)
+	if err != nil || f == nil {
+		return f == nil && err == nil, err
+	}
+	if len(params) != 1 {
+		return false, fmt.Errorf("ratio needs a ratio, e.g. ratio(16/9)")
+	}
+
+	ratio, err := parseRatio(toString(params[0]))
+	if err != nil {
+		return false, err
+	}
+
+	config, ok, err := imageConfig(f)
+	if err != nil || !ok || config.Height == 0 {
+		return false, err
+	}
+	return math.Abs(float64(config.Width)/float64(config.Height)-ratio) <= ratio*ratioTolerance, nil
+}
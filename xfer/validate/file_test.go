@@ -0,0 +1,179 @@
+package validate
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"testing"
+)
+
+// uploadFile returns the file header of content uploaded as name, the way a handler receives it
+func uploadFile(t *testing.T, name string, content []byte) *multipart.FileHeader {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	part, err := w.CreateFormFile("file", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(content)
+	w.Close()
+
+	form, err := multipart.NewReader(body, w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return form.File["file"][0]
+}
+
+func pngImage(t *testing.T, width, height int) []byte {
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseSize(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		expected int64
+	}{
+		{"512", 512},
+		{"512B", 512},
+		{"200KB", 200 << 10},
+		{"5MB", 5 << 20},
+		{" 5 mb ", 5 << 20},
+		{"2GiB", 2 << 30},
+		{"", -1},
+		{"MB", -1},
+		{"5TB", -1},
+		{"-5MB", -1},
+		{"99999999999999999GB", -1},
+	}
+	for _, test := range tests {
+		size, err := ParseSize(test.param)
+		if test.expected < 0 {
+			assert.NotNil(t, err, test.param)
+			continue
+		}
+		assert.Nil(t, err, test.param)
+		assert.Equal(t, test.expected, size, test.param)
+	}
+}
+
+func TestDetectFileType(t *testing.T) {
+	t.Parallel()
+
+	mimeType, err := DetectFileType(uploadFile(t, "photo.jpg", pngImage(t, 2, 2)))
+	assert.Nil(t, err)
+	assert.Equal(t, "image/png", mimeType)
+
+	mimeType, err = DetectFileType(uploadFile(t, "photo.png", []byte("<html><script>alert(1)</script></html>")))
+	assert.Nil(t, err)
+	assert.Equal(t, "text/html", mimeType)
+
+	mimeType, err = DetectFileType(uploadFile(t, "empty.png", nil))
+	assert.Nil(t, err)
+	assert.Equal(t, "text/plain", mimeType)
+}
+
+func TestParseRatio(t *testing.T) {
+	t.Parallel()
+
+	for str, expected := range map[string]float64{"16/9": 16.0 / 9, "1.5": 1.5, " 4 / 3 ": 4.0 / 3, "1": 1} {
+		ratio, err := parseRatio(str)
+		assert.Nil(t, err, str)
+		assert.InDelta(t, expected, ratio, 1e-9, str)
+	}
+	for _, str := range []string{"", "16/0", "0", "-1", "a/b", "1/2/3"} {
+		_, err := parseRatio(str)
+		assert.NotNil(t, err, str)
+	}
+}
+
+type Upload struct {
+	Avatar  *multipart.FileHeader   `form:"avatar" valid:"required|maxsize(1KB)|mimes(image/png,image/jpeg)|dimensions(10,10,100,100)|ratio(1/1)"`
+	Banner  *multipart.FileHeader   `form:"banner" valid:"mimes(image/*)|ratio(16/9)"`
+	Attachs []*multipart.FileHeader `form:"attachs" valid:"mimes(application/pdf,text/plain)"`
+}
+
+func TestFileRules(t *testing.T) {
+	t.Parallel()
+
+	bag, err := ValidateStruct(Upload{
+		Avatar:  uploadFile(t, "avatar.png", pngImage(t, 50, 50)),
+		Banner:  uploadFile(t, "banner.png", pngImage(t, 1921, 1080)),
+		Attachs: []*multipart.FileHeader{uploadFile(t, "notes.txt", []byte("notes"))},
+	})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	// missing optional files are valid
+	bag, err = ValidateStruct(Upload{Avatar: uploadFile(t, "avatar.png", pngImage(t, 50, 50))})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	bag, err = ValidateStruct(Upload{
+		Avatar:  uploadFile(t, "avatar.png", pngImage(t, 200, 100)),
+		Banner:  uploadFile(t, "banner.png", []byte("%PDF-1.4 not an image")),
+		Attachs: []*multipart.FileHeader{uploadFile(t, "a.pdf", []byte("%PDF-1.4")), uploadFile(t, "b.pdf", pngImage(t, 1, 1))},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"avatar:dimensions", "avatar:ratio", "banner:mimes", "banner:ratio", "attachs[1]:mimes"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "Avatar must be an image with valid dimensions", bag.Errors()[0].Message())
+	assert.Equal(t, "Banner must be an image with a 16/9 aspect ratio", bag.Errors()[3].Message())
+
+	big := make([]byte, 2048)
+	copy(big, pngImage(t, 50, 50))
+	bag, err = ValidateStruct(Upload{Avatar: uploadFile(t, "avatar.png", big)})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"avatar:maxsize"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "Avatar must not be larger than 1KB", bag.FirstErrorMessage())
+
+	bag, err = ValidateStruct(Upload{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"avatar:required"}, embeddedErrorPaths(bag))
+}
+
+func TestDimensionsEmptyBounds(t *testing.T) {
+	t.Parallel()
+
+	type Cover struct {
+		Image *multipart.FileHeader `form:"image" valid:"dimensions(,,80,60)"`
+	}
+
+	bag, err := ValidateStruct(Cover{Image: uploadFile(t, "cover.png", pngImage(t, 8, 6))})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	bag, err = ValidateStruct(Cover{Image: uploadFile(t, "cover.png", pngImage(t, 81, 6))})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"image:dimensions"}, embeddedErrorPaths(bag))
+
+	assert.Equal(t, []interface{}{"", "", "80", "60"}, extractParams(",,80,60"))
+	assert.Equal(t, []interface{}{"a", ""}, extractParams("a,"))
+}
+
+func TestFileRulesNeedFiles(t *testing.T) {
+	t.Parallel()
+
+	_, err := ValidateVar("photo.png", "mimes(image/png)")
+	assert.NotNil(t, err)
+
+	_, err = ValidateVar(uploadFile(t, "a.png", nil), "maxsize(5XB)")
+	assert.NotNil(t, err)
+}
+
+func TestHTMLFieldsForFiles(t *testing.T) {
+	t.Parallel()
+
+	fields, err := HTMLFields(Upload{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(fields))
+	assert.Equal(t, map[string]string{"required": "", "type": "file", "accept": "image/png,image/jpeg"}, fields[0].Attributes)
+	assert.Equal(t, map[string]string{"type": "file", "accept": "image/*"}, fields[1].Attributes)
+}
@@ -0,0 +1,156 @@
+package validate
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LocaleFormat holds the conventions used to format numbers, amounts and dates in messages
+type LocaleFormat struct {
+	GroupSeparator   string
+	DecimalSeparator string
+
+	// CurrencySuffix puts the currency symbol after the amount (12,50 €) instead of before it ($12.50)
+	CurrencySuffix bool
+
+	// DateLayout is the time.Format layout used for dates without an explicit layout
+	DateLayout string
+}
+
+var localeFormats = struct {
+	sync.RWMutex
+	formats map[string]LocaleFormat
+}{
+	formats: map[string]LocaleFormat{
+		`en`: {GroupSeparator: ",", DecimalSeparator: ".", DateLayout: "Jan 2, 2006"},
+		`de`: {GroupSeparator: ".", DecimalSeparator: ",", CurrencySuffix: true, DateLayout: "02.01.2006"},
+		`es`: {GroupSeparator: ".", DecimalSeparator: ",", CurrencySuffix: true, DateLayout: "02/01/2006"},
+		`fr`: {GroupSeparator: "\u202f", DecimalSeparator: ",", CurrencySuffix: true, DateLayout: "02/01/2006"},
+	},
+}
+
+// currencySymbols maps ISO 4217 codes to their symbol. Other currencies are shown with their code.
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"INR": "₹",
+}
+
+// currencyDecimals holds the minor units of currencies that don't have 2
+var currencyDecimals = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"BHD": 3,
+	"KWD": 3,
+}
+
+// RegisterLocaleFormat sets the formatting conventions for a locale or language. Locales without conventions use
+// their language's, or the English ones if there are none.
+func RegisterLocaleFormat(locale string, format LocaleFormat) {
+	localeFormats.Lock()
+	defer localeFormats.Unlock()
+	localeFormats.formats[normalizeLocale(locale)] = format
+}
+
+func localeFormat(locale string) LocaleFormat {
+	localeFormats.RLock()
+	defer localeFormats.RUnlock()
+
+	for _, l := range localeChain(locale) {
+		if format, ok := localeFormats.formats[l]; ok {
+			return format
+		}
+	}
+	return localeFormats.formats[DefaultLocale]
+}
+
+// FormatNumber formats n with thousand separators and the given number of decimals, using the conventions of
+// locale. decimals < 0 uses as many decimals as needed.
+func FormatNumber(n float64, decimals int, locale string) string {
+	format := localeFormat(locale)
+
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+
+	sign := ""
+	if n < 0 {
+		sign = "-"
+		n = -n
+	}
+
+	digits := strconv.FormatFloat(n, 'f', decimals, 64)
+	fraction := ""
+	if idx := strings.Index(digits, "."); idx >= 0 {
+		fraction = format.DecimalSeparator + digits[idx+1:]
+		digits = digits[:idx]
+	}
+
+	// group the integer digits by 3, starting from the right
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteString(format.GroupSeparator)
+		}
+		grouped.WriteRune(digit)
+	}
+
+	return sign + grouped.String() + fraction
+}
+
+// FormatCurrency formats an amount in the given ISO 4217 currency, using the conventions of locale
+func FormatCurrency(amount float64, currency string, locale string) string {
+	currency = strings.ToUpper(currency)
+
+	decimals, ok := currencyDecimals[currency]
+	if !ok {
+		decimals = 2
+	}
+	number := FormatNumber(amount, decimals, locale)
+
+	symbol, ok := currencySymbols[currency]
+	if !ok {
+		symbol = currency
+	}
+
+	// a no-break space keeps the amount and the symbol on the same line
+	if localeFormat(locale).CurrencySuffix {
+		return number + "\u00a0" + symbol
+	}
+	if !ok {
+		return symbol + "\u00a0" + number
+	}
+	return symbol + number
+}
+
+// FormatDate formats t with layout, or with the date layout of locale if layout is empty
+func FormatDate(t time.Time, layout string, locale string) string {
+	if len(layout) == 0 {
+		layout = localeFormat(locale).DateLayout
+	}
+	return t.Format(layout)
+}
+
+// toTime converts time.Time values and RFC 3339 or yyyy-mm-dd strings (such as validator params) to a time
+func toTime(obj interface{}) (time.Time, bool) {
+	switch v := obj.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v != nil {
+			return *v, true
+		}
+	case string:
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,49 @@
+package validate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFormatNumber(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		n        float64
+		decimals int
+		locale   string
+		expected string
+	}{
+		{0, -1, `en`, "0"},
+		{999, -1, `en`, "999"},
+		{1234567.891, 2, `en`, "1,234,567.89"},
+		{-1234.5, -1, `en`, "-1,234.5"},
+		{1234567.891, 2, `de`, "1.234.567,89"},
+		{1234.5, 1, `de-AT`, "1.234,5"},
+		{1234.5, 2, `fr`, "1\u202f234,50"},
+		{1234.7, 0, `pt`, "1,235"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, FormatNumber(test.n, test.decimals, test.locale), test.locale)
+	}
+}
+
+func TestFormatCurrency(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "$1,234.50", FormatCurrency(1234.5, "USD", `en`))
+	assert.Equal(t, "1.234,50\u00a0€", FormatCurrency(1234.5, "eur", `de`))
+	assert.Equal(t, "¥1,235", FormatCurrency(1234.7, "JPY", `en`))
+	assert.Equal(t, "CHF\u00a012.00", FormatCurrency(12, "CHF", `en`))
+	assert.Equal(t, "12,00\u00a0CHF", FormatCurrency(12, "CHF", `fr`))
+}
+
+func TestFormatDate(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2021, time.March, 7, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "Mar 7, 2021", FormatDate(date, ``, `en`))
+	assert.Equal(t, "07.03.2021", FormatDate(date, ``, `de-CH`))
+	assert.Equal(t, "2021-03-07", FormatDate(date, `2006-01-02`, `de`))
+}
@@ -0,0 +1,172 @@
+//go:build ignore
+
+// gen_confusables generates confusables.go from the Unicode confusables table of UTS #39 (see
+// https://www.unicode.org/reports/tr39/). Run it with go generate. The table version is pinned, so the generated
+// file only changes when unicodeVersion does.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// unicodeVersion is the version of the confusables table
+const unicodeVersion = "13.0.0"
+
+var (
+	data   = flag.String("data", "", "read confusables.txt from this file instead of downloading it")
+	output = flag.String("output", "confusables.go", "file to write")
+)
+
+func main() {
+	flag.Parse()
+
+	input, err := openConfusables()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer input.Close()
+
+	confusables, err := parseConfusables(input)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := format.Source(render(confusables))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func openConfusables() (io.ReadCloser, error) {
+	if *data != "" {
+		return os.Open(*data)
+	}
+
+	url := "https://www.unicode.org/Public/security/" + unicodeVersion + "/confusables.txt"
+	log.Println("Downloading " + url)
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// parseConfusables reads the lines of confusables.txt, e.g.
+//
+//	0430 ;	0061 ;	MA	# ( а → a ) CYRILLIC SMALL LETTER A → LATIN SMALL LETTER A	#
+//
+// and keeps the ones kept by isWanted
+func parseConfusables(input io.Reader) (map[rune]rune, error) {
+	confusables := make(map[rune]rune)
+
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		line := strings.TrimPrefix(scanner.Text(), "\ufeff")
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		fields := strings.Split(line, ";")
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("Invalid line %q", line)
+		}
+		if strings.TrimSpace(fields[2]) != "MA" {
+			continue
+		}
+
+		source, err := parseCodePoints(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		prototype, err := parseCodePoints(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		if len(source) == 1 && len(prototype) == 1 && isWanted(source[0], prototype[0]) {
+			confusables[source[0]] = prototype[0]
+		}
+	}
+
+	return confusables, scanner.Err()
+}
+
+func parseCodePoints(field string) ([]rune, error) {
+	var runes []rune
+	for _, code := range strings.Fields(field) {
+		r, err := strconv.ParseUint(code, 16, 32)
+		if err != nil || r > unicode.MaxRune {
+			return nil, fmt.Errorf("Invalid code point %q", code)
+		}
+		runes = append(runes, rune(r))
+	}
+	return runes, nil
+}
+
+// isWanted is true for the letters and digits that look like an ASCII letter or digit. Compatibility variants are
+// left out, as the validate package finds them through NFKC.
+func isWanted(source, prototype rune) bool {
+	if source < utf8.RuneSelf || !(unicode.IsLetter(source) || unicode.IsDigit(source)) {
+		return false
+	}
+	if prototype >= utf8.RuneSelf || !(unicode.IsLetter(prototype) || unicode.IsDigit(prototype)) {
+		return false
+	}
+	return norm.NFKC.String(string(source)) == string(source)
+}
+
+func render(confusables map[rune]rune) []byte {
+	sources := make([]rune, 0, len(confusables))
+	for source := range confusables {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i] < sources[j] })
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen_confusables.go from confusables.txt %s; DO NOT EDIT.\n\n", unicodeVersion)
+	fmt.Fprintf(&b, "package validate\n\n")
+	fmt.Fprintf(&b, "// confusables maps the letters and digits that look like an ASCII letter or digit to it. It's the part of the\n")
+	fmt.Fprintf(&b, "// Unicode %s confusables table (https://www.unicode.org/Public/security/%s/confusables.txt) whose\n", unicodeVersion, unicodeVersion)
+	fmt.Fprintf(&b, "// source is a non-ASCII letter or digit and whose prototype is an ASCII letter or digit. Compatibility variants of\n")
+	fmt.Fprintf(&b, "// ASCII, such as fullwidth letters, aren't listed; isASCIILookalike finds them through NFKC.\n")
+	fmt.Fprintf(&b, "var confusables = map[rune]rune{\n")
+	for _, source := range sources {
+		fmt.Fprintf(&b, "\t0x%04x: %q, // %s\n", source, confusables[source], displayed(source))
+	}
+	fmt.Fprintf(&b, "}\n")
+	return b.Bytes()
+}
+
+// displayed returns the source rune as shown in the comments, leaving out the ones that change the text direction
+func displayed(r rune) string {
+	if unicode.In(r, unicode.Arabic, unicode.Hebrew, unicode.Nko, unicode.Syriac, unicode.Thaana, unicode.Samaritan,
+		unicode.Mandaic, unicode.Adlam) {
+		return fmt.Sprintf("U+%04X", r)
+	}
+	return string(r)
+}
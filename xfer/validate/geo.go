@@ -0,0 +1,152 @@
+package validate
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxLatitude  = 90
+	maxLongitude = 180
+)
+
+// latitudeFields and longitudeFields are the names, lower cased, of the struct fields latlng reads coordinates from
+var (
+	latitudeFields  = []string{"lat", "latitude"}
+	longitudeFields = []string{"lng", "lon", "long", "longitude"}
+)
+
+// coordinateValue returns the value of a coordinate given as a number or a numeric string. ok is false for other
+// values, NaN and infinities.
+func coordinateValue(v reflect.Value) (coord float64, ok bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		coord = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		coord = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		coord = v.Float()
+	case reflect.String:
+		var err error
+		if coord, err = strconv.ParseFloat(strings.TrimSpace(v.String()), 64); err != nil {
+			return 0, false
+		}
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return 0, false
+		}
+		return coordinateValue(v.Elem())
+	default:
+		return 0, false
+	}
+	return coord, !math.IsNaN(coord) && !math.IsInf(coord, 0)
+}
+
+// IsLatLng check if lat and lng are valid WGS84 coordinates, in degrees
+func IsLatLng(lat, lng float64) bool {
+	return math.Abs(lat) <= maxLatitude && math.Abs(lng) <= maxLongitude
+}
+
+// isLatitude is the latitude validator: strings are checked with IsLatitude, numbers must be within ±90
+func isLatitude(val interface{}, params ...interface{}) bool {
+	if str, ok := val.(string); ok {
+		return IsLatitude(str)
+	}
+	lat, ok := coordinateValue(reflect.ValueOf(val))
+	return ok && IsLatLng(lat, 0)
+}
+
+// isLongitude is the longitude validator: strings are checked with IsLongitude, numbers must be within ±180
+func isLongitude(val interface{}, params ...interface{}) bool {
+	if str, ok := val.(string); ok {
+		return IsLongitude(str)
+	}
+	lng, ok := coordinateValue(reflect.ValueOf(val))
+	return ok && IsLatLng(0, lng)
+}
+
+// fieldByNames returns the struct field whose name is one of names, ignoring case
+func fieldByNames(v reflect.Value, names []string) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		for _, name := range names {
+			if strings.EqualFold(field.Name, name) {
+				return v.Field(i), true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// latLngPair returns the coordinates of a latlng value: a struct with Lat/Latitude and Lng/Lon/Long/Longitude
+// fields, a slice or array of two coordinates or a "lat,lng" string. Slices are [lat, lng]; the lnglat param reads
+// them the GeoJSON way, [lng, lat].
+func latLngPair(v reflect.Value, lngFirst bool) (lat, lng float64, ok bool) {
+	var latValue, lngValue reflect.Value
+	switch v.Kind() {
+	case reflect.Struct:
+		var latOk, lngOk bool
+		latValue, latOk = fieldByNames(v, latitudeFields)
+		lngValue, lngOk = fieldByNames(v, longitudeFields)
+		if !latOk || !lngOk {
+			return 0, 0, false
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Len() != 2 {
+			return 0, 0, false
+		}
+		latValue, lngValue = v.Index(0), v.Index(1)
+		if lngFirst {
+			latValue, lngValue = lngValue, latValue
+		}
+	case reflect.String:
+		parts := strings.Split(v.String(), ",")
+		if len(parts) != 2 {
+			return 0, 0, false
+		}
+		latValue, lngValue = reflect.ValueOf(parts[0]), reflect.ValueOf(parts[1])
+	default:
+		return 0, 0, false
+	}
+
+	lat, latOk := coordinateValue(latValue)
+	lng, lngOk := coordinateValue(lngValue)
+	return lat, lng, latOk && lngOk
+}
+
+// isLatLngPair is the latlng validator for coordinate pairs (see latLngPair). Empty slices and strings are valid;
+// use required for them. The coordinates of a slice are checked with the slice, so numbers and numeric strings are
+// valid on their own.
+func isLatLngPair(val interface{}, params ...interface{}) bool {
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Invalid, reflect.Ptr, reflect.Interface:
+		// pointers are checked with the value they point to
+		return true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.String:
+		if v.Len() == 0 {
+			return true
+		}
+		if !strings.Contains(v.String(), ",") {
+			_, ok := coordinateValue(v)
+			return ok
+		}
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return true
+		}
+	}
+
+	lngFirst := len(params) > 0 && strings.EqualFold(strings.TrimSpace(toString(params[0])), "lnglat")
+	lat, lng, ok := latLngPair(v, lngFirst)
+	return ok && IsLatLng(lat, lng)
+}
@@ -0,0 +1,95 @@
+package validate
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestLatitudeLongitudeNumbers(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param     interface{}
+		latitude  bool
+		longitude bool
+	}{
+		{"47.1231231", true, true},
+		{"108", false, true},
+		{47.1231231, true, true},
+		{float32(-90), true, true},
+		{90.0001, false, true},
+		{-180.0, false, true},
+		{180.5, false, false},
+		{int64(45), true, true},
+		{uint8(120), false, true},
+		{math.NaN(), false, false},
+		{math.Inf(1), false, false},
+		{true, false, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.latitude, isLatitude(test.param), "latitude "+fmt.Sprint(test.param))
+		assert.Equal(t, test.longitude, isLongitude(test.param), "longitude "+fmt.Sprint(test.param))
+	}
+}
+
+type LatLng struct {
+	Lat float64
+	Lng float64
+}
+
+type Position struct {
+	Latitude  string
+	Longitude string
+}
+
+type Place struct {
+	Name     string     `json:"name"`
+	Lat      float64    `json:"lat" valid:"latitude"`
+	Lng      float32    `json:"lng" valid:"longitude"`
+	Center   LatLng     `json:"center" valid:"latlng"`
+	Entrance *Position  `json:"entrance" valid:"latlng"`
+	Point    []float64  `json:"point" valid:"latlng(lnglat)"`
+	Pin      [2]float64 `json:"pin" valid:"latlng"`
+	Location string     `json:"location" valid:"latlng"`
+}
+
+func TestLatLngRule(t *testing.T) {
+	t.Parallel()
+
+	bag, err := ValidateStruct(Place{
+		Lat:      47.37,
+		Lng:      8.54,
+		Center:   LatLng{Lat: 47.37, Lng: 8.54},
+		Entrance: &Position{Latitude: "47.37", Longitude: "8.54"},
+		Point:    []float64{8.54, 47.37},
+		Pin:      [2]float64{-33.87, 151.21},
+		Location: "47.37, 8.54",
+	})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	// zero values are coordinates too, and empty slices and strings are left to required
+	bag, err = ValidateStruct(Place{})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	bag, err = ValidateStruct(Place{
+		Lat:      91,
+		Lng:      -181,
+		Center:   LatLng{Lat: 8.54, Lng: 470},
+		Entrance: &Position{Latitude: "north", Longitude: "8.54"},
+		Point:    []float64{47.37, 100},
+		Pin:      [2]float64{-33.87, 181},
+		Location: "47.37, 200",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"lat:latitude", "lng:longitude", "center:latlng", "entrance:latlng", "point:latlng", "pin:latlng",
+		"location:latlng"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "Center must be a valid pair of coordinates", bag.Errors()[2].Message())
+
+	bag, err = ValidateStruct(Place{Point: []float64{1, 2, 3}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"point:latlng"}, embeddedErrorPaths(bag))
+}
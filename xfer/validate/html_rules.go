@@ -0,0 +1,240 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// HTMLField holds the HTML5 input attributes that mirror the server side validations on a single struct field,
+// along with the message to show when the browser rejects the input. Attributes without a value (required) are
+// set to an empty string.
+type HTMLField struct {
+	// Key is the form key of the field: its form tag, then its json tag, then the field name
+	Key        string
+	Name       string
+	Attributes map[string]string
+
+	// Messages maps an attribute name to the message of the validator that produced it
+	Messages map[string]string
+}
+
+// htmlAttributesFunc returns the HTML attributes for a validator given the field kind and validator params. A nil
+// or empty map means the validator has no client side equivalent.
+type htmlAttributesFunc func(kind reflect.Kind, params []interface{}) map[string]string
+
+// htmlAttributeMap holds the client side equivalents for validator keys. Validators that aren't listed here are
+// only checked on the server.
+var htmlAttributeMap = map[string]htmlAttributesFunc{
+	"required": func(kind reflect.Kind, params []interface{}) map[string]string {
+		return map[string]string{"required": ""}
+	},
+	"between": func(kind reflect.Kind, params []interface{}) map[string]string {
+		if len(params) != 2 {
+			return nil
+		}
+		if kind == reflect.String {
+			return map[string]string{"minlength": toString(params[0]), "maxlength": toString(params[1])}
+		}
+		return map[string]string{"min": toString(params[0]), "max": toString(params[1])}
+	},
+	"matches": func(kind reflect.Kind, params []interface{}) map[string]string {
+		if len(params) != 1 {
+			return nil
+		}
+		// StringMatches treats the pattern as a literal, and the pattern attribute is always anchored
+		return map[string]string{"pattern": ".*" + regexp.QuoteMeta(toString(params[0])) + ".*"}
+	},
+	"email":       htmlType("email"),
+	"url":         htmlType("url"),
+	"requrl":      htmlType("url"),
+	"phone":       htmlType("tel"),
+	"alpha":       htmlPattern(Alpha),
+	"alphanum":    htmlPattern(Alphanumeric),
+	"numeric":     htmlPattern(Numeric),
+	"hexadecimal": htmlPattern(Hexadecimal),
+	"hexcolor":    htmlPattern(Hexcolor),
+	"uuid":        htmlPattern(UUID),
+	"int":         htmlNumber("1"),
+	"float":       htmlNumber("any"),
+	"port": func(kind reflect.Kind, params []interface{}) map[string]string {
+		return map[string]string{"type": "number", "min": "1", "max": "65535"}
+	},
+	"positive": func(kind reflect.Kind, params []interface{}) map[string]string {
+		// min is inclusive, so it only works for integers
+		if kind == reflect.Float32 || kind == reflect.Float64 || kind == reflect.String {
+			return map[string]string{"type": "number"}
+		}
+		return map[string]string{"type": "number", "min": "1"}
+	},
+	"nonnegative": func(kind reflect.Kind, params []interface{}) map[string]string {
+		return map[string]string{"type": "number", "min": "0"}
+	},
+	"negative": func(kind reflect.Kind, params []interface{}) map[string]string {
+		// max is inclusive, so it only works for integers
+		if kind == reflect.Float32 || kind == reflect.Float64 || kind == reflect.String {
+			return map[string]string{"type": "number"}
+		}
+		return map[string]string{"type": "number", "max": "-1"}
+	},
+	"nonpositive": func(kind reflect.Kind, params []interface{}) map[string]string {
+		return map[string]string{"type": "number", "max": "0"}
+	},
+	"whole": htmlNumber("1"),
+	"natural": func(kind reflect.Kind, params []interface{}) map[string]string {
+		return map[string]string{"type": "number", "min": "1", "step": "1"}
+	},
+	"divisibleby": htmlStep,
+	"multipleof":  htmlStep,
+	"mimes": func(kind reflect.Kind, params []interface{}) map[string]string {
+		accept := make([]string, len(params))
+		for i, param := range params {
+			accept[i] = strings.TrimSpace(toString(param))
+		}
+		return map[string]string{"type": "file", "accept": strings.Join(accept, ",")}
+	},
+}
+
+func htmlType(inputType string) htmlAttributesFunc {
+	return func(kind reflect.Kind, params []interface{}) map[string]string {
+		return map[string]string{"type": inputType}
+	}
+}
+
+// htmlPattern converts one of the anchored patterns in patterns.go to a pattern attribute
+func htmlPattern(pattern string) htmlAttributesFunc {
+	return func(kind reflect.Kind, params []interface{}) map[string]string {
+		if kind != reflect.String {
+			return nil
+		}
+		return map[string]string{"pattern": strings.TrimSuffix(strings.TrimPrefix(pattern, "^"), "$")}
+	}
+}
+
+func htmlNumber(step string) htmlAttributesFunc {
+	return func(kind reflect.Kind, params []interface{}) map[string]string {
+		return map[string]string{"type": "number", "step": step}
+	}
+}
+
+// htmlStep is the step attribute of divisibleby and multipleof. Steps count from min, which is 0 by default.
+func htmlStep(kind reflect.Kind, params []interface{}) map[string]string {
+	if len(params) != 1 {
+		return nil
+	}
+	return map[string]string{"type": "number", "step": strings.TrimSpace(toString(params[0]))}
+}
+
+// HTMLFields returns the HTML5 input attributes and messages for each exported field of s, in struct field order.
+// It uses the same valid tags as ValidateStruct, and the same rules for embedded structs. Negated validators can't
+// be expressed as HTML attributes and are skipped, as are nested structs (except uploaded files), maps and slices.
+// Names and messages are in the default locale (see SetMessagesLocale).
+func HTMLFields(s interface{}) ([]HTMLField, error) {
+	return HTMLFieldsContext(context.Background(), s)
+}
+
+// HTMLFieldsContext is HTMLFields with names and messages in the locale of ctx (see WithLocale)
+func HTMLFieldsContext(ctx context.Context, s interface{}) ([]HTMLField, error) {
+
+	obj := reflect.ValueOf(s)
+	if obj.Kind() == reflect.Interface || obj.Kind() == reflect.Ptr {
+		obj = obj.Elem()
+	}
+
+	if obj.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("HTMLFields only accepts structs; got %s", obj.Kind())
+	}
+
+	return appendHTMLFields(make([]HTMLField, 0, obj.NumField()), obj, nil, LocaleFromContext(ctx))
+}
+
+// appendHTMLFields appends the fields of obj to fields, including the promoted fields of embedded structs that
+// aren't shadowed (see validateStructFields), with names and messages in locale
+func appendHTMLFields(fields []HTMLField, obj reflect.Value, shadowed map[string]bool, locale string) ([]HTMLField, error) {
+
+	declared := make(map[string]bool, len(shadowed)+obj.NumField())
+	for name := range shadowed {
+		declared[name] = true
+	}
+	for i := 0; i < obj.NumField(); i++ {
+		if typeField := obj.Type().Field(i); !typeField.Anonymous {
+			declared[typeField.Name] = true
+		}
+	}
+
+	for i := 0; i < obj.NumField(); i++ {
+		valueField := obj.Field(i)
+		typeField := obj.Type().Field(i)
+		if shadowed[typeField.Name] {
+			continue
+		}
+
+		if isPromotingEmbeddedStruct(typeField) {
+			if typeField.Tag.Get(tagName) == "-" {
+				continue
+			}
+			if valueField.Kind() == reflect.Ptr {
+				if valueField.IsNil() {
+					valueField = reflect.Zero(typeField.Type.Elem())
+				} else {
+					valueField = valueField.Elem()
+				}
+			}
+			var err error
+			if fields, err = appendHTMLFields(fields, valueField, declared, locale); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if typeField.PkgPath != "" {
+			continue // Private field
+		}
+
+		kind := typeField.Type.Kind()
+		if kind == reflect.Ptr {
+			kind = typeField.Type.Elem().Kind()
+		}
+		switch kind {
+		case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Interface:
+			if !isFileType(typeField.Type) {
+				continue
+			}
+		}
+
+		fieldValidators, err := getFieldValidators(valueField, typeField, obj, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		field := HTMLField{
+			Key:        fieldKey(typeField),
+			Name:       newFieldLabel(obj, typeField, ``).in(locale),
+			Attributes: make(map[string]string),
+			Messages:   make(map[string]string),
+		}
+
+		for _, fv := range fieldValidators {
+			field.Name = fv.labelIn(locale)
+			if fv.IsNegated {
+				continue
+			}
+
+			attrsFunc, ok := htmlAttributeMap[fv.ValidatorKey]
+			if !ok {
+				continue
+			}
+
+			for name, value := range attrsFunc(kind, fv.ValidatorParams) {
+				field.Attributes[name] = value
+				field.Messages[name] = fv.MessageIn(locale)
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,107 @@
+package validate
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHTMLFields(t *testing.T) {
+	t.Parallel()
+
+	type SignupForm struct {
+		Email    string `form:"email" valid:"required|email"`
+		Nickname string `json:"nickname,omitempty" valid:"between(3,20)"`
+		Age      int    `valid:"between(18,130)|name=Your age"`
+		Code     string `valid:"!alpha"`
+		Address  struct {
+			City string `valid:"required"`
+		}
+	}
+
+	fields, err := HTMLFields(&SignupForm{})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(fields))
+
+	assert.Equal(t, "email", fields[0].Key)
+	assert.Equal(t, map[string]string{"required": "", "type": "email"}, fields[0].Attributes)
+	assert.Equal(t, "Email must not be empty", fields[0].Messages["required"])
+	assert.Equal(t, "Email must be a valid address", fields[0].Messages["type"])
+
+	assert.Equal(t, "nickname", fields[1].Key)
+	assert.Equal(t, map[string]string{"minlength": "3", "maxlength": "20"}, fields[1].Attributes)
+
+	assert.Equal(t, "Age", fields[2].Key)
+	assert.Equal(t, "Your age", fields[2].Name)
+	assert.Equal(t, map[string]string{"min": "18", "max": "130"}, fields[2].Attributes)
+
+	assert.Equal(t, 0, len(fields[3].Attributes), "negated validators have no HTML equivalent")
+}
+
+func TestHTMLFieldsContext(t *testing.T) {
+	t.Parallel()
+
+	type LoginForm struct {
+		Email string `form:"email" valid:"required|email"`
+	}
+
+	fields, err := HTMLFieldsContext(WithLocale(context.Background(), "de"), LoginForm{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Email darf nicht leer sein", fields[0].Messages["required"])
+	assert.Equal(t, "Email muss eine gültige Adresse sein", fields[0].Messages["type"])
+
+	fields, err = HTMLFieldsContext(WithLocale(context.Background(), "fr"), LoginForm{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Email ne doit pas être vide", fields[0].Messages["required"])
+	assert.Equal(t, "Email doit être une adresse valide", fields[0].Messages["type"])
+}
+
+func TestHTMLFieldsRejectsNonStructs(t *testing.T) {
+	t.Parallel()
+
+	_, err := HTMLFields("not a struct")
+	assert.NotNil(t, err)
+}
+
+func TestHTMLFieldsPromotesEmbeddedFields(t *testing.T) {
+	t.Parallel()
+
+	type GuestForm struct {
+		BaseForm
+		Email string `json:"email" valid:"email"`
+		Notes string `json:"notes" valid:"between(0,500)"`
+	}
+
+	fields, err := HTMLFields(GuestForm{})
+	assert.Nil(t, err)
+
+	keys := []string{}
+	for _, f := range fields {
+		keys = append(keys, f.Key)
+	}
+	assert.Equal(t, []string{"name", "email", "notes"}, keys)
+	assert.Equal(t, map[string]string{"type": "email"}, fields[1].Attributes)
+}
+
+func TestHTMLFieldKeysMatchErrorKeys(t *testing.T) {
+	t.Parallel()
+
+	type ProfileForm struct {
+		Nickname string `json:"nickname,omitempty" valid:"required"`
+		Email    string `form:"email" json:"mail" valid:"email"`
+		Age      int    `valid:"between(18,130)"`
+	}
+
+	fields, err := HTMLFields(ProfileForm{})
+	assert.Nil(t, err)
+	bag, err := ValidateStruct(ProfileForm{Email: "nope", Age: 3})
+	assert.Nil(t, err)
+
+	keys := []string{}
+	for _, f := range fields {
+		keys = append(keys, f.Key)
+	}
+	assert.Equal(t, []string{"nickname", "email", "Age"}, keys)
+	assert.Equal(t, keys, bag.Keys())
+	assert.Equal(t, []string{"nickname:required", "email:email", "Age:between"}, embeddedErrorPaths(bag))
+}
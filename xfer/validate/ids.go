@@ -0,0 +1,59 @@
+package validate
+
+import (
+	"regexp"
+
+	fstrings "github.com/jjharr/genesis/framework/utils/strings"
+)
+
+// maxKSUID is the largest KSUID, as 27 base62 digits. Larger strings of the same length overflow its 160 bits.
+const maxKSUID = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+
+var (
+	// ULIDs are 26 Crockford base32 digits, which leave out I, L, O and U. The first digit only holds 3 bits.
+	rxULID  = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`)
+	rxKSUID = regexp.MustCompile(`^[0-9A-Za-z]{27}$`)
+
+	// rxSlug matches what fstrings.ToDash returns: lower case words of letters and digits joined by single dashes
+	rxSlug = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+// IsULID check if the string is a ULID, in either case. Empty string is valid.
+func IsULID(str string, params ...interface{}) bool {
+	if IsNull(str) {
+		return true
+	}
+	return rxULID.MatchString(str)
+}
+
+// IsKSUID check if the string is a KSUID. Empty string is valid.
+func IsKSUID(str string, params ...interface{}) bool {
+	if IsNull(str) {
+		return true
+	}
+	// base62 digits are in ASCII order, so KSUIDs compare as strings
+	return rxKSUID.MatchString(str) && str <= maxKSUID
+}
+
+// isMongoID is the mongoid validator: IsMongoID, but empty string is valid
+func isMongoID(val interface{}, params ...interface{}) bool {
+	if str, ok := val.(string); ok && IsNull(str) {
+		return true
+	}
+	return IsMongoID(val, params...)
+}
+
+// IsSlug check if the string is a URL slug, such as the ones made by the slug sanitizer: lower case ASCII letters
+// and digits, with single dashes between words. Empty string is valid.
+func IsSlug(str string, params ...interface{}) bool {
+	if IsNull(str) {
+		return true
+	}
+	return rxSlug.MatchString(str)
+}
+
+// Slug turns a string into a URL slug with fstrings.ToDash, e.g. "Hello, World" into hello-world. Characters
+// other than ASCII letters and digits separate words and are dropped.
+func Slug(str string, params ...interface{}) string {
+	return fstrings.ToDash(str)
+}
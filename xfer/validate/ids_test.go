@@ -0,0 +1,106 @@
+package validate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+
+	fstrings "github.com/jjharr/genesis/framework/utils/strings"
+)
+
+func TestIsULID(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		expected bool
+	}{
+		{"", true},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAV", true},
+		{"01arz3ndektsv4rrffq69g5fav", true},
+		{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ", true},
+		{"8ZZZZZZZZZZZZZZZZZZZZZZZZZ", false},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FA", false},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAVX", false},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAU", false},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAI", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsULID(test.param), test.param)
+	}
+}
+
+func TestIsKSUID(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		expected bool
+	}{
+		{"", true},
+		{"0ujtsYcgvSTl8PAuAdqWYSMnLOv", true},
+		{"000000000000000000000000000", true},
+		{"aWgEPTl1tmebfsQzFP4bxwgy80V", true},
+		{"aWgEPTl1tmebfsQzFP4bxwgy80W", false},
+		{"zzzzzzzzzzzzzzzzzzzzzzzzzzz", false},
+		{"0ujtsYcgvSTl8PAuAdqWYSMnLO", false},
+		{"0ujtsYcgvSTl8PAuAdqWYSMnLO-", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsKSUID(test.param), test.param)
+	}
+}
+
+func TestIsSlug(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		expected bool
+	}{
+		{"", true},
+		{"hello", true},
+		{"hello-world-2", true},
+		{"2024", true},
+		{"Hello-World", false},
+		{"hello--world", false},
+		{"-hello", false},
+		{"hello-", false},
+		{"hello_world", false},
+		{"héllo", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsSlug(test.param), test.param)
+	}
+
+	// the slug sanitizer and fstrings.ToDash make valid slugs
+	for _, str := range []string{"Hello, World!", "HTTPServer config", "user_id", "  Über 9000  "} {
+		assert.Equal(t, fstrings.ToDash(str), Slug(str))
+		assert.True(t, IsSlug(Slug(str)), str)
+	}
+	assert.Equal(t, "hello-world", Slug("Hello, World!"))
+}
+
+type Article struct {
+	ID      string `json:"id" valid:"required|ulid"`
+	TraceID string `json:"trace_id" valid:"ksuid"`
+	Slug    string `json:"slug" sanitize:"slug" valid:"required|slug"`
+	Owner   string `json:"owner" valid:"mongoid"`
+}
+
+func TestIDRules(t *testing.T) {
+	t.Parallel()
+
+	article := Article{ID: "01ARZ3NDEKTSV4RRFFQ69G5FAV", Slug: "My First Post", Owner: "507f1f77bcf86cd799439011"}
+	assert.Nil(t, SanitizeStruct(&article))
+	assert.Equal(t, "my-first-post", article.Slug)
+
+	bag, err := ValidateStruct(article)
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	bag, err = ValidateStruct(Article{ID: "01ARZ3NDEKTSV4RRFFQ69G5FA", TraceID: "0ujtsYcgvSTl8PAuAdqWYSMnLO", Slug: "My First Post",
+		Owner: "507f1f77"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"id:ulid", "trace_id:ksuid", "slug:slug", "owner:mongoid"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "Slug must only contain lower case letters, digits and dashes between words", bag.Errors()[2].Message())
+}
@@ -0,0 +1,169 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	fstrings "github.com/jjharr/genesis/framework/utils/strings"
+)
+
+// labelTagName is the struct tag read by TagLabels
+const labelTagName = "label"
+
+// LabelProvider returns the label of a struct field, used as {field} in messages and as the field of errors. owner
+// is the struct type holding the field, or nil for ValidateVar and ValidateSlice. ok is false if the provider has
+// no label for the field.
+//
+// A name= setting in the valid tag always takes precedence over the provider.
+type LabelProvider interface {
+	Label(owner reflect.Type, field reflect.StructField, locale string) (label string, ok bool)
+}
+
+// LabelProviderFunc adapts a function to the LabelProvider interface
+type LabelProviderFunc func(owner reflect.Type, field reflect.StructField, locale string) (string, bool)
+
+func (f LabelProviderFunc) Label(owner reflect.Type, field reflect.StructField, locale string) (string, bool) {
+	return f(owner, field, locale)
+}
+
+// TagLabels reads labels from the label struct tag: Email string `label:"E-mail address"`
+var TagLabels LabelProvider = LabelProviderFunc(func(owner reflect.Type, field reflect.StructField, locale string) (string, bool) {
+	label := field.Tag.Get(labelTagName)
+	return label, len(label) > 0
+})
+
+// TitleLabels converts field names to title case words with fstrings.ToTitle, so UserEmail is labelled User Email.
+// Acronyms are kept together: UserID is labelled User ID.
+var TitleLabels LabelProvider = LabelProviderFunc(func(owner reflect.Type, field reflect.StructField, locale string) (string, bool) {
+	return titleCase(field.Name), len(field.Name) > 0
+})
+
+// titleCase is fstrings.ToTitle, with the letters of acronyms joined again: ToTitle splits words before every upper
+// case letter, so UserID is User I D and HTTP2Server is H T T P2 Server.
+func titleCase(name string) string {
+	words := strings.Fields(fstrings.ToTitle(name))
+	joined := make([]string, 0, len(words))
+	for i, w := range words {
+		if i > 0 && isAcronymLetter(w) && isAcronymLetter(words[i-1]) {
+			joined[len(joined)-1] += w
+			continue
+		}
+		joined = append(joined, w)
+	}
+	return strings.Join(joined, " ")
+}
+
+// isAcronymLetter is true for a word of ToTitle that is an upper case letter, possibly followed by digits
+func isAcronymLetter(w string) bool {
+	if w[0] < 'A' || w[0] > 'Z' {
+		return false
+	}
+	for _, c := range w[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ChainLabels returns a provider that asks each provider in turn, and uses the first label found
+func ChainLabels(providers ...LabelProvider) LabelProvider {
+	return LabelProviderFunc(func(owner reflect.Type, field reflect.StructField, locale string) (string, bool) {
+		for _, p := range providers {
+			if label, ok := p.Label(owner, field, locale); ok {
+				return label, true
+			}
+		}
+		return ``, false
+	})
+}
+
+// LabelCatalogue holds translated labels, keyed by locale and then by struct.field (SignupForm.UserEmail). Labels
+// missing from a locale fall back to its parents and finally to DefaultLocale, like messages.
+type LabelCatalogue struct {
+	sync.RWMutex
+	labels map[string]map[string]string
+}
+
+func NewLabelCatalogue() *LabelCatalogue {
+	return &LabelCatalogue{labels: make(map[string]map[string]string)}
+}
+
+// Add merges labels into the catalogue for a locale
+func (c *LabelCatalogue) Add(locale string, labels map[string]string) {
+	c.Lock()
+	defer c.Unlock()
+
+	locale = normalizeLocale(locale)
+	if c.labels[locale] == nil {
+		c.labels[locale] = make(map[string]string, len(labels))
+	}
+	for key, label := range labels {
+		c.labels[locale][key] = label
+	}
+}
+
+func (c *LabelCatalogue) Label(owner reflect.Type, field reflect.StructField, locale string) (string, bool) {
+	if owner == nil {
+		return ``, false
+	}
+	key := owner.Name() + "." + field.Name
+
+	c.RLock()
+	defer c.RUnlock()
+
+	for _, l := range localeChain(locale) {
+		if label, ok := c.labels[l][key]; ok {
+			return label, true
+		}
+	}
+	return ``, false
+}
+
+var labelProvider = struct {
+	sync.RWMutex
+	provider LabelProvider
+}{provider: ChainLabels(TagLabels, TitleLabels)}
+
+// SetLabelProvider sets the provider used to label fields. The default reads the label tag, then converts the field
+// name with TitleLabels. To use a catalogue, chain it before the default:
+//
+//	SetLabelProvider(ChainLabels(TagLabels, catalogue, TitleLabels))
+func SetLabelProvider(p LabelProvider) {
+	labelProvider.Lock()
+	defer labelProvider.Unlock()
+	labelProvider.provider = p
+}
+
+// fieldLabel resolves the label of a field in any locale
+type fieldLabel struct {
+	owner reflect.Type
+	field reflect.StructField
+
+	// name is the name= setting of the valid tag, which is used as is
+	name string
+}
+
+func newFieldLabel(o reflect.Value, t reflect.StructField, name string) fieldLabel {
+	l := fieldLabel{field: t, name: name}
+	if o.IsValid() {
+		l.owner = o.Type()
+	}
+	return l
+}
+
+func (l fieldLabel) in(locale string) string {
+	if len(l.name) > 0 {
+		return l.name
+	}
+
+	labelProvider.RLock()
+	p := labelProvider.provider
+	labelProvider.RUnlock()
+
+	if label, ok := p.Label(l.owner, l.field, locale); ok {
+		return label
+	}
+	return l.field.Name
+}
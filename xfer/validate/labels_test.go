@@ -0,0 +1,88 @@
+package validate
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type ContactForm struct {
+	UserEmail string `json:"user_email" valid:"required"`
+	UserID    string `json:"user_id" valid:"required"`
+	Phone     string `json:"phone" label:"Phone number" valid:"required"`
+	Nickname  string `json:"nickname" label:"Nickname" valid:"required|name=Alias"`
+}
+
+func errorFields(bag *ErrorBag) []string {
+	fields := []string{}
+	for _, e := range bag.Errors() {
+		fields = append(fields, e.Field)
+	}
+	return fields
+}
+
+func TestDefaultLabels(t *testing.T) {
+	t.Parallel()
+
+	bag, err := ValidateStruct(ContactForm{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"User Email", "User ID", "Phone number", "Alias"}, errorFields(bag))
+	assert.Equal(t, "User Email must not be empty", bag.FirstErrorMessage())
+}
+
+func TestTitleCase(t *testing.T) {
+	t.Parallel()
+
+	samples := map[string]string{
+		"Email":        "Email",
+		"UserEmail":    "User Email",
+		"UserID":       "User ID",
+		"HTTPServer":   "HTTP Server",
+		"HTTP2Server":  "HTTP2 Server",
+		"Address2Line": "Address2 Line",
+		"user_name":    "User Name",
+	}
+	for name, expected := range samples {
+		assert.Equal(t, expected, titleCase(name), name)
+	}
+}
+
+func TestLabelCatalogue(t *testing.T) {
+	catalogue := NewLabelCatalogue()
+	catalogue.Add(`en`, map[string]string{"ContactForm.UserEmail": "Email address"})
+	catalogue.Add(`de`, map[string]string{"ContactForm.UserEmail": "E-Mail-Adresse", "ContactForm.Phone": "Telefon"})
+
+	SetLabelProvider(ChainLabels(catalogue, TagLabels, TitleLabels))
+	defer SetLabelProvider(ChainLabels(TagLabels, TitleLabels))
+
+	bag, err := ValidateStruct(ContactForm{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Email address", "User ID", "Phone number", "Alias"}, errorFields(bag))
+
+	bag, err = ValidateStructContext(WithLocale(context.Background(), `de-AT`), ContactForm{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"E-Mail-Adresse", "User ID", "Telefon", "Alias"}, errorFields(bag))
+	assert.Equal(t, "E-Mail-Adresse darf nicht leer sein", bag.FirstErrorMessage())
+
+	// errors rendered again in another locale get the label of that locale
+	localized := bag.Localize(`fr`)
+	assert.Equal(t, "Email address", localized.Errors()[0].Field)
+	assert.Equal(t, "Email address ne doit pas être vide", localized.FirstErrorMessage())
+}
+
+func TestCustomLabelProvider(t *testing.T) {
+	SetLabelProvider(LabelProviderFunc(func(owner reflect.Type, field reflect.StructField, locale string) (string, bool) {
+		return "«" + field.Name + "»", true
+	}))
+	defer SetLabelProvider(ChainLabels(TagLabels, TitleLabels))
+
+	bag, err := ValidateVar("", "required")
+	assert.Nil(t, err)
+	assert.Equal(t, "«Value» must not be empty", bag.FirstErrorMessage())
+
+	fields, err := HTMLFields(ContactForm{})
+	assert.Nil(t, err)
+	assert.Equal(t, "«UserEmail»", fields[0].Name)
+	assert.Equal(t, "Alias", fields[3].Name)
+}
@@ -0,0 +1,187 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// placeholder styles, used as {name, style} or {name, style, argument}
+const (
+	// {max, number} or {max, number, 2} for a fixed number of decimals
+	styleNumber = `number`
+	// {value, date} for the locale's date layout, or {value, date, 2006-01-02}
+	styleDate = `date`
+	// {value, currency, EUR}
+	styleCurrency = `currency`
+)
+
+// otherFieldPrefix prefixes placeholders that refer to another field of the same struct, e.g. {other.Email}
+const otherFieldPrefix = `other.`
+
+// placeholder is a parsed message placeholder. Besides the styles above, placeholders can use a fmt verb:
+// {value:%.2f}
+type placeholder struct {
+	name     string
+	verb     string
+	style    string
+	argument string
+}
+
+func parsePlaceholder(raw string) (placeholder, error) {
+	p := placeholder{name: raw, verb: "%v"}
+
+	if parts := strings.Split(raw, ","); len(parts) > 1 {
+		if len(parts) > 3 {
+			return p, fmt.Errorf("placeholder {%s} has too many parts", raw)
+		}
+		p.name = strings.TrimSpace(parts[0])
+		p.style = strings.TrimSpace(parts[1])
+		if len(parts) == 3 {
+			p.argument = strings.TrimSpace(parts[2])
+		}
+
+		switch p.style {
+		case styleNumber:
+			if _, err := strconv.Atoi(p.argument); len(p.argument) > 0 && err != nil {
+				return p, fmt.Errorf("placeholder {%s} must give the number of decimals as an integer", raw)
+			}
+		case styleDate:
+		case styleCurrency:
+			if len(p.argument) != 3 {
+				return p, fmt.Errorf("placeholder {%s} must give a currency code", raw)
+			}
+		default:
+			return p, fmt.Errorf("placeholder {%s} has an unknown style %s", raw, p.style)
+		}
+	} else if idx := strings.Index(raw, ":"); idx >= 0 {
+		p.name = raw[:idx]
+		p.verb = raw[idx+1:]
+	}
+
+	if len(p.name) == 0 {
+		return p, fmt.Errorf("placeholder {%s} has no name", raw)
+	}
+
+	return p, nil
+}
+
+// render formats value according to the placeholder style. Values that don't fit the style (such as a redacted
+// value) are rendered as is.
+func (p placeholder) render(value interface{}, locale string) string {
+	switch p.style {
+	case styleNumber, styleCurrency:
+		n, err := toFloat(toString(value))
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		if p.style == styleCurrency {
+			return FormatCurrency(n, p.argument, locale)
+		}
+		decimals := -1
+		if len(p.argument) > 0 {
+			decimals, _ = strconv.Atoi(p.argument)
+		}
+		return FormatNumber(n, decimals, locale)
+	case styleDate:
+		t, ok := toTime(value)
+		if !ok {
+			return fmt.Sprint(value)
+		}
+		return FormatDate(t, p.argument, locale)
+	}
+
+	return fmt.Sprintf(p.verb, value)
+}
+
+// fillMessagePlaceholders replaces the placeholders in msg with the matching values, formatted for locale.
+// Unknown placeholders are rejected when tags are parsed (see checkMessagePlaceholders), so any left here come from
+// a bundle that isn't used by the default locale, and are kept as is.
+func fillMessagePlaceholders(msg string, values map[string]interface{}, locale string) string {
+	if strings.Index(msg, "{") < 0 {
+		return msg
+	}
+	return customMessageVarRegex.ReplaceAllStringFunc(msg, func(raw string) string {
+		p, err := parsePlaceholder(raw[1 : len(raw)-1])
+		if err != nil {
+			return raw
+		}
+		value, found := values[p.name]
+		if !found {
+			return raw
+		}
+		return p.render(value, locale)
+	})
+}
+
+// checkMessagePlaceholders returns the names of the placeholders in msg, or an error if one is malformed or isn't
+// in names
+func checkMessagePlaceholders(msg string, names map[string]bool) ([]string, error) {
+	var referenced []string
+	for _, raw := range customMessageVarRegex.FindAllString(msg, -1) {
+		p, err := parsePlaceholder(raw[1 : len(raw)-1])
+		if err != nil {
+			return nil, err
+		}
+		if !names[p.name] {
+			return nil, fmt.Errorf("unknown placeholder {%s}", p.name)
+		}
+		referenced = append(referenced, p.name)
+	}
+	return referenced, nil
+}
+
+// messageCheckKey identifies the messages of a validator on a struct field. The placeholders they can use only
+// depend on the struct type and the validator directive, so they're checked once per key (see messageChecks).
+type messageCheckKey struct {
+	parent    reflect.Type
+	field     string
+	directive string
+}
+
+// messageCheck is the result of FieldValidator.checkMessages
+type messageCheck struct {
+	otherFields []string
+	err         error
+}
+
+// messageChecks caches the message checks done when tags are parsed, which would otherwise run for every field on
+// every validation. It's reset whenever messages or validators change.
+var messageChecks = struct {
+	sync.RWMutex
+	checks map[messageCheckKey]messageCheck
+}{checks: map[messageCheckKey]messageCheck{}}
+
+// cachedMessageCheck returns the result of ms.checkMessages for key, running it the first time only
+func cachedMessageCheck(key messageCheckKey, ms FieldValidator) ([]string, error) {
+	messageChecks.RLock()
+	check, ok := messageChecks.checks[key]
+	messageChecks.RUnlock()
+
+	if !ok {
+		check.otherFields, check.err = ms.checkMessages()
+		messageChecks.Lock()
+		messageChecks.checks[key] = check
+		messageChecks.Unlock()
+	}
+	return check.otherFields, check.err
+}
+
+func resetMessageChecks() {
+	messageChecks.Lock()
+	defer messageChecks.Unlock()
+	messageChecks.checks = map[messageCheckKey]messageCheck{}
+}
+
+// checkPlaceholderSyntax returns an error if msg has a malformed placeholder. Unlike checkMessagePlaceholders, it
+// doesn't check names, which depend on the validator and field the message is used for.
+func checkPlaceholderSyntax(msg string) error {
+	for _, raw := range customMessageVarRegex.FindAllString(msg, -1) {
+		if _, err := parsePlaceholder(raw[1 : len(raw)-1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
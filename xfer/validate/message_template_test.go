@@ -0,0 +1,127 @@
+package validate
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMessageParamPlaceholders(t *testing.T) {
+	t.Parallel()
+
+	type Order struct {
+		Quantity int    `valid:"between(1,1000)->{field} must be at most {max, number}, not {value, number}"`
+		Code     string `valid:"between(2,4)->{field} needs {0} to {1} characters"`
+	}
+
+	bag, err := ValidateStruct(Order{Quantity: 2500, Code: "ABCDEF"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Quantity must be at most 1,000, not 2,500", bag.Errors()[0].Message())
+	assert.Equal(t, "Code needs 2 to 4 characters", bag.Errors()[1].Message())
+}
+
+func TestMessageOtherFieldPlaceholders(t *testing.T) {
+	t.Parallel()
+
+	type Transfer struct {
+		Email    string    `json:"email"`
+		Pin      string    `json:"pin" valid:"redact=true"`
+		Amount   float64   `json:"amount" valid:"between(1,100)->{other.Email} can't send {value, currency, EUR} (pin {other.Pin})"`
+		Deadline time.Time `json:"deadline"`
+	}
+
+	transfer := Transfer{Email: "ann@example.com", Pin: "1234", Amount: 1500.5}
+
+	bag, err := ValidateStructContext(WithLocale(context.Background(), `de`), transfer)
+	assert.Nil(t, err)
+	assert.Equal(t, "ann@example.com can't send 1.500,50\u00a0€ (pin [redacted])", bag.FirstErrorMessage())
+
+	// only the fields the messages refer to are read
+	others := []string{}
+	for name := range bag.Errors()[0].values {
+		if strings.HasPrefix(name, otherFieldPrefix) {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	assert.Equal(t, []string{"other.Email", "other.Pin"}, others)
+}
+
+func TestMessageDatePlaceholders(t *testing.T) {
+	t.Parallel()
+
+	type Booking struct {
+		Start time.Time
+		Name  string `valid:"required->{field} is required from {other.Start, date} ({other.Start, date, 2006-01-02})"`
+	}
+
+	bag, err := ValidateStruct(Booking{Start: time.Date(2021, time.March, 7, 0, 0, 0, 0, time.UTC)})
+	assert.Nil(t, err)
+	assert.Equal(t, "Name is required from Mar 7, 2021 (2021-03-07)", bag.FirstErrorMessage())
+}
+
+func TestUnknownPlaceholderIsAnError(t *testing.T) {
+	t.Parallel()
+
+	type Typo struct {
+		Name string `valid:"between(1,5)->{field} must be at most {maximum}"`
+	}
+	_, err := ValidateStruct(Typo{Name: "Anastasia"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "{maximum}")
+
+	type MissingField struct {
+		Name string `valid:"required->{field} or {other.Nickname} is required"`
+	}
+	_, err = ValidateStruct(MissingField{})
+	assert.NotNil(t, err)
+
+	type BadStyle struct {
+		Name string `valid:"required->{field} is required by {value, time}"`
+	}
+	_, err = ValidateStruct(BadStyle{})
+	assert.NotNil(t, err)
+
+	// the between message uses {min} and {max}, which don't exist without params
+	_, err = ValidateVar("x", "between")
+	assert.NotNil(t, err)
+}
+
+func TestValidatorCustomMessagePlaceholders(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, RegisterValidator("testshouted", EmValidator{OpString: IsUpperCase}))
+
+	type Shout struct {
+		Word string `valid:"testshouted"`
+	}
+
+	assert.NotNil(t, SetCustomMessage("testshouted", "{field} must be shouted, not {value, shouting}"))
+	assert.Nil(t, SetCustomMessage("testshouted", "{field} must be shouted, not {valu}"))
+	_, err := ValidateStruct(Shout{Word: "hey"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "{valu}")
+
+	assert.Nil(t, SetCustomMessage("testshouted", "{field} must be shouted, not {value}"))
+	bag, err := ValidateStruct(Shout{Word: "hey"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Word must be shouted, not hey", bag.FirstErrorMessage())
+
+	assert.Nil(t, SetCustomMessage("testshouted", "%s must be shouted"))
+	bag, err = ValidateStruct(Shout{Word: "hey"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Word must be shouted", bag.FirstErrorMessage())
+}
+
+func TestBundlePlaceholderSyntaxIsChecked(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseMessageBundle(`it`, []byte(`{"between.message": "{field} deve essere tra {min, numero} e {max}"}`))
+	assert.NotNil(t, err)
+
+	_, err = ParseMessageBundle(`it`, []byte(`{"between.message": "{field} deve essere tra {min, number} e {max, number}"}`))
+	assert.Nil(t, err)
+}
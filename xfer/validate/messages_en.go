@@ -1,9 +1,67 @@
 package validate
 
 var messages_en = map[string]string{
+	`required.message`: `{field} must not be empty`,
+
+	`between.message`: `{field} must be between {min} and {max}`,
+
+	`positive.messagefmt`:        `%s must be a positive number`,
+	`positive.negatedmessagefmt`: `%s must not be a positive number`,
+
+	`nonnegative.messagefmt`:        `%s must be a number that isn't negative`,
+	`nonnegative.negatedmessagefmt`: `%s must be a negative number`,
+
+	`negative.messagefmt`:        `%s must be a negative number`,
+	`negative.negatedmessagefmt`: `%s must not be a negative number`,
+
+	`nonpositive.messagefmt`:        `%s must be a number that isn't positive`,
+	`nonpositive.negatedmessagefmt`: `%s must be a positive number`,
+
+	`whole.messagefmt`:        `%s must be a whole number`,
+	`whole.negatedmessagefmt`: `%s must not be a whole number`,
+
+	`natural.messagefmt`:        `%s must be a whole number greater than zero`,
+	`natural.negatedmessagefmt`: `%s must not be a whole number greater than zero`,
+
+	`divisibleby.message`: `{field} must be a whole number divisible by {divisor}`,
+
+	`multipleof.message`: `{field} must be a multiple of {step}`,
+
 	`email.messagefmt`:        `%s must be a valid address`,
 	`email.negatedmessagefmt`: `%s must not be an email address`,
 
+	`nodisposable.messagefmt`:        `%s must not be a disposable address`,
+	`nodisposable.negatedmessagefmt`: `%s must be a disposable address`,
+
+	`mx.messagefmt`:        `%s must be an address that can receive email`,
+	`mx.negatedmessagefmt`: `%s must not be an address that can receive email`,
+
+	`phone.messagefmt`:        `%s must be a valid phone number`,
+	`phone.negatedmessagefmt`: `%s must not be a phone number`,
+
+	`password.message`:           `{field} is too weak: {reasons}`,
+	`password.minlength.message`: `use at least {min} characters`,
+	`password.maxlength.message`: `use at most {max} characters`,
+	`password.lower.message`:     `add a lower case letter`,
+	`password.upper.message`:     `add an upper case letter`,
+	`password.digit.message`:     `add a digit`,
+	`password.symbol.message`:    `add a symbol`,
+	`password.classes.message`:   `mix at least {min} of lower case letters, upper case letters, digits and symbols`,
+	`password.entropy.message`:   `make it less predictable`,
+	`password.forbidden.message`: `don't use your name or email address`,
+	`password.breached.message`:  `choose one that hasn't appeared in a data breach`,
+
+	`maxsize.message`: `{field} must not be larger than {size}`,
+
+	`mimes.message`: `{field} must be a file of an allowed type`,
+
+	`dimensions.message`: `{field} must be an image with valid dimensions`,
+
+	`ratio.message`: `{field} must be an image with a {ratio} aspect ratio`,
+
+	`safehtml.messagefmt`:        `%s contains HTML that isn't allowed`,
+	`safehtml.negatedmessagefmt`: `%s must contain HTML that isn't allowed`,
+
 	`url.messagefmt`:        `%s must be a full URL`,
 	`url.negatedmessagefmt`: `%s must not be a URL`,
 
@@ -70,9 +128,33 @@ var messages_en = map[string]string{
 	`uuid5.messagefmt`:        `%s must be a UUID (v5)`,
 	`uuid5.negatedmessagefmt`: `%s must not be a UUID (v5)`,
 
+	`ulid.messagefmt`:        `%s must be a ULID`,
+	`ulid.negatedmessagefmt`: `%s must not be a ULID`,
+
+	`ksuid.messagefmt`:        `%s must be a KSUID`,
+	`ksuid.negatedmessagefmt`: `%s must not be a KSUID`,
+
+	`slug.messagefmt`:        `%s must only contain lower case letters, digits and dashes between words`,
+	`slug.negatedmessagefmt`: `%s must not be a slug`,
+
 	`creditcard.messagefmt`:        `%s must be a valid credit card number`,
 	`creditcard.negatedmessagefmt`: `%s must not be a credit card number`,
 
+	`cardexpiry.messagefmt`:        `%s must be a valid expiry date (MM/YY) that hasn't passed`,
+	`cardexpiry.negatedmessagefmt`: `%s must not be a valid expiry date`,
+
+	`cvc.messagefmt`:        `%s must be a valid security code`,
+	`cvc.negatedmessagefmt`: `%s must not be a security code`,
+
+	`iban.messagefmt`:        `%s must be a valid IBAN`,
+	`iban.negatedmessagefmt`: `%s must not be an IBAN`,
+
+	`bic.messagefmt`:        `%s must be a valid BIC`,
+	`bic.negatedmessagefmt`: `%s must not be a BIC`,
+
+	`vatid.messagefmt`:        `%s must be a valid VAT number`,
+	`vatid.negatedmessagefmt`: `%s must not be a VAT number`,
+
 	`json.messagefmt`:        `%s must be a valid JSON`,
 	`json.negatedmessagefmt`: `%s must not be JSON`,
 
@@ -94,6 +176,15 @@ var messages_en = map[string]string{
 	`variablewidth.messagefmt`:        `%s must contain variable-width UTF characters`,
 	`variablewidth.negatedmessagefmt`: `%s must not contain variable-width UTF characters`,
 
+	`printable.messagefmt`:        `%s must not contain invisible or control characters`,
+	`printable.negatedmessagefmt`: `%s must contain invisible or control characters`,
+
+	`singlescript.messagefmt`:        `%s must be written in a single script`,
+	`singlescript.negatedmessagefmt`: `%s must not be written in a single script`,
+
+	`noconfusable.messagefmt`:        `%s must not contain look-alike characters`,
+	`noconfusable.negatedmessagefmt`: `%s must contain look-alike characters`,
+
 	`base64.messagefmt`:        `%s must be valid Base64`,
 	`base64.negatedmessagefmt`: `%s must not be Base64`,
 
@@ -106,6 +197,18 @@ var messages_en = map[string]string{
 	`ipv4.messagefmt`:        `%s must be a valid IPv4 address`,
 	`ipv4.negatedmessagefmt`: `%s must not be an IPv4 address`,
 
+	`ipv6.messagefmt`:        `%s must be a valid IPv6 address`,
+	`ipv6.negatedmessagefmt`: `%s must not be an IPv6 address`,
+
+	`cidr.messagefmt`:        `%s must be a valid network in CIDR notation`,
+	`cidr.negatedmessagefmt`: `%s must not be a network in CIDR notation`,
+
+	`ipin.messagefmt`:        `%s must be an IP address in an allowed range`,
+	`ipin.negatedmessagefmt`: `%s must not be an IP address in this range`,
+
+	`publicip.messagefmt`:        `%s must be a public IP address`,
+	`publicip.negatedmessagefmt`: `%s must not be a public IP address`,
+
 	`dns.messagefmt`:        `%s must be a valid DNS name`,
 	`dns.negatedmessagefmt`: `%s must not be a DNS name`,
 
@@ -121,9 +224,21 @@ var messages_en = map[string]string{
 	`longitude.messagefmt`:        `%s must be a valid longitude`,
 	`longitude.negatedmessagefmt`: `%s must not be a longitude`,
 
+	`latlng.messagefmt`:        `%s must be a valid pair of coordinates`,
+	`latlng.negatedmessagefmt`: `%s must not be a pair of coordinates`,
+
+	`postcode.messagefmt`:        `%s must be a valid postal code`,
+	`postcode.negatedmessagefmt`: `%s must not be a postal code`,
+
 	`ssn.messagefmt`:        `%s must be a valid SSN`,
 	`ssn.negatedmessagefmt`: `%s must not be a SSN`,
 
 	`semver.messagefmt`:        `%s must be a valid semantic version`,
 	`semver.negatedmessagefmt`: `%s must not be a semantic version`,
+
+	`semverrange.messagefmt`:        `%s must be a supported version`,
+	`semverrange.negatedmessagefmt`: `%s must not be one of these versions`,
+
+	`mongoid.messagefmt`:        `%s must be a valid MongoDB ObjectId`,
+	`mongoid.negatedmessagefmt`: `%s must not be a MongoDB ObjectId`,
 }
@@ -0,0 +1,158 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// nonPublicNetworks are the networks publicip rejects: addresses that reach the server itself, its private network
+// or cloud metadata services, and addresses that aren't routable on the internet. Requests to them from user
+// supplied URLs (webhooks...) are how SSRF attacks reach internal services.
+var nonPublicNetworks = mustParseCIDRs(
+	"0.0.0.0/8",          // "this" network
+	"10.0.0.0/8",         // private
+	"100.64.0.0/10",      // carrier-grade NAT
+	"127.0.0.0/8",        // loopback
+	"169.254.0.0/16",     // link-local, including the 169.254.169.254 metadata service
+	"172.16.0.0/12",      // private
+	"192.0.0.0/24",       // IETF protocol assignments
+	"192.0.2.0/24",       // documentation
+	"192.168.0.0/16",     // private
+	"198.18.0.0/15",      // benchmarking
+	"198.51.100.0/24",    // documentation
+	"203.0.113.0/24",     // documentation
+	"224.0.0.0/4",        // multicast
+	"240.0.0.0/4",        // reserved, and broadcast
+	"100.100.100.200/32", // Alibaba Cloud metadata service
+	"::/128",             // unspecified
+	"::1/128",            // loopback
+	"::/96",              // IPv4-compatible, which can embed any IPv4 address
+	"::ffff:0:0:0/96",    // IPv4-translated, which can embed any IPv4 address
+	"64:ff9b::/96",       // IPv4/IPv6 translation, which can embed any IPv4 address
+	"100::/64",           // discard
+	"2001::/23",          // IETF protocol assignments, including Teredo, which can embed any IPv4 address
+	"2001:db8::/32",      // documentation
+	"2002::/16",          // 6to4, which can embed any IPv4 address
+	"fc00::/7",           // unique local, including the fd00:ec2::254 metadata service
+	"fe80::/10",          // link-local
+	"ff00::/8",           // multicast
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err.Error())
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+// IsCIDR check if the string is an IPv4 or IPv6 network in CIDR notation, e.g. 10.0.0.0/8. Empty string is valid.
+func IsCIDR(str string, params ...interface{}) bool {
+	if IsNull(str) {
+		return true
+	}
+	_, _, err := net.ParseCIDR(str)
+	return err == nil
+}
+
+// parseIPRange parses an ipin param: a network in CIDR notation or a single address
+func parseIPRange(str string) (*net.IPNet, error) {
+	str = strings.TrimSpace(str)
+	if strings.Contains(str, "/") {
+		_, network, err := net.ParseCIDR(str)
+		return network, err
+	}
+
+	ip := net.ParseIP(str)
+	if ip == nil {
+		return nil, fmt.Errorf("%q is not an IP address or CIDR network", str)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+// isIPIn is the ipin(10.0.0.0/8,192.168.1.10) validator: the value must be an address in one of the networks or
+// addresses given as params. Empty values are ignored; use required for them.
+func isIPIn(ctx context.Context, val interface{}, params ...interface{}) (bool, error) {
+	str, ok := val.(string)
+	if !ok {
+		return false, fmt.Errorf("ipin only validates strings; got %T", val)
+	}
+	if len(params) == 0 {
+		return false, fmt.Errorf("ipin needs networks, e.g. ipin(10.0.0.0/8)")
+	}
+
+	networks := make([]*net.IPNet, len(params))
+	for i, param := range params {
+		network, err := parseIPRange(toString(param))
+		if err != nil {
+			return false, err
+		}
+		networks[i] = network
+	}
+
+	if len(str) == 0 {
+		return true, nil
+	}
+	ip := net.ParseIP(str)
+	if ip == nil {
+		return false, nil
+	}
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IsPublicAddr is true if ip is a public internet address (see IsPublicIP). IPv4-mapped IPv6 addresses are checked
+// as IPv4 addresses. Use it to check the resolved address of user supplied hosts before connecting, e.g. in a
+// net.Dialer Control function, as a host name can resolve to an internal address.
+func IsPublicAddr(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPublicIP check if the string is a public internet address. Loopback, private, link-local, multicast, reserved
+// and cloud metadata addresses are rejected, as are IPv6 transition addresses that can embed any IPv4 address. Host
+// names are rejected too: resolve them and check the addresses with IsPublicAddr. Empty string is valid.
+func IsPublicIP(str string, params ...interface{}) bool {
+	if IsNull(str) {
+		return true
+	}
+	return IsPublicAddr(net.ParseIP(str))
+}
+
+// isIPv6 is the ipv6 validator: IsIPv6, but empty string is valid
+func isIPv6(val interface{}, params ...interface{}) bool {
+	if str, ok := val.(string); ok && IsNull(str) {
+		return true
+	}
+	return IsIPv6(val, params...)
+}
+
+// isAllowedScheme is true if the URL scheme is one of the url rule params, ignoring case
+func isAllowedScheme(scheme string, params []interface{}) bool {
+	for _, param := range params {
+		if strings.EqualFold(strings.TrimSpace(toString(param)), scheme) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package validate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+)
+
+func TestIsCIDR(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		expected bool
+	}{
+		{"", true},
+		{"10.0.0.0/8", true},
+		{"192.168.1.10/32", true},
+		{"2001:db8::/32", true},
+		{"10.0.0.0", false},
+		{"10.0.0.0/33", false},
+		{"10.0.0/8", false},
+		{"example.com/8", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsCIDR(test.param), test.param)
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		expected bool
+	}{
+		{"", true},
+		{"8.8.8.8", true},
+		{"93.184.216.34", true},
+		{"2606:4700:4700::1111", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.31.255.255", false},
+		{"172.32.0.1", true},
+		{"192.168.0.1", false},
+		{"100.64.0.1", false},
+		{"169.254.169.254", false},
+		{"100.100.100.200", false},
+		{"0.0.0.0", false},
+		{"255.255.255.255", false},
+		{"224.0.0.1", false},
+		{"::1", false},
+		{"::", false},
+		{"fe80::1", false},
+		{"fd00:ec2::254", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:8.8.8.8", true},
+		{"2002:7f00:1::", false},
+		{"64:ff9b::a9fe:a9fe", false},
+		{"::7f00:1", false},
+		{"::a9fe:a9fe", false},
+		{"::ffff:0:7f00:1", false},
+		{"2001:0:4136:e378:8000:63bf:3fff:fdd2", false},
+		{"2001:1ff::1", false},
+		{"2001:200::1", true},
+		{"localhost", false},
+		{"example.com", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsPublicIP(test.param), test.param)
+	}
+
+	assert.False(t, IsPublicAddr(nil))
+	assert.True(t, IsPublicAddr(net.IPv4(1, 1, 1, 1)))
+}
+
+func TestIsURLSchemes(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		expected bool
+	}{
+		{"", true},
+		{"https://example.com/hook", true},
+		{"http://example.com/hook", false},
+		{"ftp://example.com/hook", false},
+		{"example.com/hook", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsURL(test.param, "https"), test.param)
+	}
+	assert.True(t, IsURL("http://example.com", "HTTPS", " http "))
+}
+
+type Webhook struct {
+	URL     string   `json:"url" valid:"required|url(https)"`
+	Address string   `json:"address" valid:"publicip"`
+	Network string   `json:"network" valid:"cidr"`
+	Client  string   `json:"client" valid:"ipin(10.0.0.0/8, 192.168.1.10)"`
+	Gateway string   `json:"gateway" valid:"ipv6"`
+	Owner   string   `json:"owner" valid:"mongoid"`
+	Allowed []string `json:"allowed" valid:"cidr"`
+}
+
+func TestNetworkRules(t *testing.T) {
+	t.Parallel()
+
+	bag, err := ValidateStruct(Webhook{
+		URL:     "https://example.com/hook",
+		Address: "93.184.216.34",
+		Network: "10.0.0.0/8",
+		Client:  "192.168.1.10",
+		Gateway: "2001:db8::1",
+		Owner:   "507f1f77bcf86cd799439011",
+		Allowed: []string{"10.0.0.0/8", "fd00::/8"},
+	})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	bag, err = ValidateStruct(Webhook{URL: "https://example.com/hook", Client: "10.200.0.1", Gateway: "::1",
+		Owner: "507F1F77BCF86CD799439011"})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	bag, err = ValidateStruct(Webhook{URL: "https://example.com/hook"})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	bag, err = ValidateStruct(Webhook{
+		URL:     "http://example.com/hook",
+		Address: "169.254.169.254",
+		Network: "10.0.0.0",
+		Client:  "192.168.1.11",
+		Gateway: "10.0.0.1",
+		Owner:   "507f1f77",
+		Allowed: []string{"10.0.0.0/8", "fd00::"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"url:url", "address:publicip", "network:cidr", "client:ipin", "gateway:ipv6", "owner:mongoid",
+		"allowed[1]:cidr"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "Address must be a public IP address", bag.Errors()[1].Message())
+	assert.Equal(t, "Client must be an IP address in an allowed range", bag.Errors()[3].Message())
+}
+
+func TestIPInNeedsNetworks(t *testing.T) {
+	t.Parallel()
+
+	_, err := ValidateVar("10.0.0.1", "ipin(10.0.0.0/33)")
+	assert.NotNil(t, err)
+
+	_, err = ValidateVar("10.0.0.1", "ipin(intranet)")
+	assert.NotNil(t, err)
+
+	_, err = ValidateVar(10, "ipin(10.0.0.0/8)")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,273 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// numberKind tells which field of a number holds its value
+type numberKind int
+
+const (
+	intNumber numberKind = iota
+	uintNumber
+	floatNumber
+)
+
+// number is a numeric value of any kind, so numeric rules don't need a type switch. Integers keep their exact
+// value as an int64 or uint64 rather than going through float64, which can't hold all of them.
+type number struct {
+	kind numberKind
+	i    int64
+	u    uint64
+	f    float64
+}
+
+// twoPow63 and twoPow64 are the float64 bounds of int64 and uint64. They're exact, unlike MaxInt64 and MaxUint64,
+// which round up to them.
+const (
+	twoPow63 = float64(1 << 63)
+	twoPow64 = twoPow63 * 2
+)
+
+// numberOf returns the number held by v: an integer, a float or a numeric string, directly or through pointers
+// and interfaces. ok is false for other values and NaN.
+func numberOf(v reflect.Value) (n number, ok bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number{kind: intNumber, i: v.Int()}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return number{kind: uintNumber, u: v.Uint()}, true
+	case reflect.Float32:
+		// float32 values are read as the decimal they print as, so float32(0.1) is 0.1 rather than 0.100000001
+		f, _ := strconv.ParseFloat(strconv.FormatFloat(v.Float(), 'g', -1, 32), 64)
+		return number{kind: floatNumber, f: f}, !math.IsNaN(f)
+	case reflect.Float64:
+		return number{kind: floatNumber, f: v.Float()}, !math.IsNaN(v.Float())
+	case reflect.String:
+		return parseNumber(v.String())
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return n, false
+		}
+		return numberOf(v.Elem())
+	}
+	return n, false
+}
+
+// numberOfValue is numberOf for validator values and params
+func numberOfValue(val interface{}) (number, bool) {
+	return numberOf(reflect.ValueOf(val))
+}
+
+// parseNumber parses a decimal number. Integers are parsed exactly, as int64 or as uint64 if they don't fit.
+func parseNumber(str string) (n number, ok bool) {
+	str = strings.TrimSpace(str)
+	if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+		return number{kind: intNumber, i: i}, true
+	}
+	if u, err := strconv.ParseUint(str, 10, 64); err == nil {
+		return number{kind: uintNumber, u: u}, true
+	}
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil || math.IsNaN(f) {
+		return n, false
+	}
+	return number{kind: floatNumber, f: f}, true
+}
+
+// float64 returns n as a float64, rounding large integers
+func (n number) float64() float64 {
+	switch n.kind {
+	case intNumber:
+		return float64(n.i)
+	case uintNumber:
+		return float64(n.u)
+	}
+	return n.f
+}
+
+// sign returns -1, 0 or 1 as n is negative, zero or positive
+func (n number) sign() int {
+	switch {
+	case n.kind == intNumber && n.i < 0, n.kind == floatNumber && n.f < 0:
+		return -1
+	case n.kind == intNumber && n.i == 0, n.kind == uintNumber && n.u == 0, n.kind == floatNumber && n.f == 0:
+		return 0
+	}
+	return 1
+}
+
+// isWhole is true if n has no fractional part. Infinities aren't whole.
+func (n number) isWhole() bool {
+	return n.kind != floatNumber || (n.f == math.Trunc(n.f) && !math.IsInf(n.f, 0))
+}
+
+// cmp returns -1, 0 or 1 as n is less than, equal to or greater than o. It's exact for all kinds: a negative
+// int64 is less than any uint64, and floats are compared to integers without rounding the integers.
+func (n number) cmp(o number) int {
+	switch {
+	case n.kind == floatNumber && o.kind == floatNumber:
+		return compareFloat(n.f, o.f)
+	case n.kind == floatNumber:
+		return -o.cmpFloat(n.f)
+	case o.kind == floatNumber:
+		return n.cmpFloat(o.f)
+	case n.kind == intNumber && o.kind == intNumber:
+		return compareInt(n.i, o.i)
+	case n.kind == uintNumber && o.kind == uintNumber:
+		return compareUint(n.u, o.u)
+	case n.kind == intNumber:
+		if n.i < 0 {
+			return -1
+		}
+		return compareUint(uint64(n.i), o.u)
+	default:
+		if o.i < 0 {
+			return 1
+		}
+		return compareUint(n.u, uint64(o.i))
+	}
+}
+
+// cmpFloat compares the integer n to f. f is split into its integer part, which is compared as an integer once
+// known to be in range, and its fraction, which breaks ties.
+func (n number) cmpFloat(f float64) int {
+	if math.IsInf(f, 0) {
+		return -int(math.Copysign(1, f))
+	}
+
+	whole, frac := math.Modf(f)
+	var c int
+	switch {
+	case n.kind == intNumber && whole < -twoPow63:
+		return 1
+	case n.kind == intNumber && whole >= twoPow63:
+		return -1
+	case n.kind == intNumber:
+		c = compareInt(n.i, int64(whole))
+	case whole < 0:
+		return 1
+	case whole >= twoPow64:
+		return -1
+	default:
+		c = compareUint(n.u, uint64(whole))
+	}
+
+	if c == 0 {
+		return -compareFloat(frac, 0)
+	}
+	return c
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// numberBetween is true if n is within min and max, inclusive
+func numberBetween(n, min, max number) bool {
+	return n.cmp(min) >= 0 && n.cmp(max) <= 0
+}
+
+// rat returns n as an exact fraction. Floats are read as the shortest decimal that prints as them, so 0.07 is
+// 7/100 and not the binary fraction closest to it. ok is false for infinities.
+func (n number) rat() (r *big.Rat, ok bool) {
+	switch n.kind {
+	case intNumber:
+		return new(big.Rat).SetInt64(n.i), true
+	case uintNumber:
+		return new(big.Rat).SetUint64(n.u), true
+	}
+	if math.IsInf(n.f, 0) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(strconv.FormatFloat(n.f, 'g', -1, 64))
+}
+
+// isMultipleOf is true if n is a whole multiple of step, which mustn't be zero
+func (n number) isMultipleOf(step number) bool {
+	x, okX := n.rat()
+	y, okY := step.rat()
+	if !okX || !okY || y.Sign() == 0 {
+		return false
+	}
+	return new(big.Rat).Quo(x, y).IsInt()
+}
+
+// numberRule makes a validator out of a check on numbers. It accepts every numeric kind and numeric strings;
+// empty strings are valid, use required for them, and other values aren't.
+func numberRule(check func(n number) bool) func(val interface{}, params ...interface{}) bool {
+	return func(val interface{}, params ...interface{}) bool {
+		if str, ok := val.(string); ok && IsNull(str) {
+			return true
+		}
+		n, ok := numberOfValue(val)
+		return ok && check(n)
+	}
+}
+
+var (
+	isPositiveNumber    = numberRule(func(n number) bool { return n.sign() > 0 })
+	isNonNegativeNumber = numberRule(func(n number) bool { return n.sign() >= 0 })
+	isNegativeNumber    = numberRule(func(n number) bool { return n.sign() < 0 })
+	isNonPositiveNumber = numberRule(func(n number) bool { return n.sign() <= 0 })
+	isWholeNumber       = numberRule(number.isWhole)
+	isNaturalNumber     = numberRule(func(n number) bool { return n.sign() > 0 && n.isWhole() })
+)
+
+// stepParam returns the number a divisibleby or multipleof param holds, which must be positive, and whole for
+// divisibleby
+func stepParam(key string, params []interface{}, whole bool) (number, error) {
+	if len(params) != 1 {
+		return number{}, fmt.Errorf("%s needs a number, e.g. %s(5)", key, key)
+	}
+	step, ok := numberOfValue(params[0])
+	if !ok || step.sign() <= 0 || (whole && !step.isWhole()) || math.IsInf(step.float64(), 0) {
+		return number{}, fmt.Errorf("Invalid %s param %q", key, toString(params[0]))
+	}
+	return step, nil
+}
+
+// isDivisibleBy is the divisibleby(5) validator: the value must be a whole number divisible by the param
+func isDivisibleBy(ctx context.Context, val interface{}, params ...interface{}) (bool, error) {
+	divisor, err := stepParam("divisibleby", params, true)
+	if err != nil {
+		return false, err
+	}
+	return numberRule(func(n number) bool {
+		return n.isWhole() && n.isMultipleOf(divisor)
+	})(val), nil
+}
+
+// isMultipleOf is the multipleof(0.01) validator: the value must be a whole number of steps, e.g. a price in
+// cents. Decimals are compared exactly, so 0.07 is a multiple of 0.01 even though neither is exact as a float.
+func isMultipleOf(ctx context.Context, val interface{}, params ...interface{}) (bool, error) {
+	step, err := stepParam("multipleof", params, false)
+	if err != nil {
+		return false, err
+	}
+	return numberRule(func(n number) bool {
+		return n.isMultipleOf(step)
+	})(val), nil
+}
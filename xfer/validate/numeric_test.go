@@ -0,0 +1,366 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+// numericKinds returns n as a value of every numeric kind it fits in, exactly
+func numericKinds(n int64) []interface{} {
+	values := []interface{}{int(n), int64(n), float64(n), fmt.Sprint(n)}
+	if n >= math.MinInt8 && n <= math.MaxInt8 {
+		values = append(values, int8(n))
+	}
+	if n >= math.MinInt16 && n <= math.MaxInt16 {
+		values = append(values, int16(n), float32(n))
+	}
+	if n >= math.MinInt32 && n <= math.MaxInt32 {
+		values = append(values, int32(n))
+	}
+	if n >= 0 {
+		values = append(values, uint(n), uint64(n), uintptr(n))
+	}
+	if n >= 0 && n <= math.MaxUint8 {
+		values = append(values, uint8(n))
+	}
+	if n >= 0 && n <= math.MaxUint16 {
+		values = append(values, uint16(n))
+	}
+	if n >= 0 && n <= math.MaxUint32 {
+		values = append(values, uint32(n))
+	}
+	return values
+}
+
+func TestNumberOf(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int64{0, 1, -1, 127, -128, 255, 65535, 65536, math.MaxInt32, math.MinInt32} {
+		for _, val := range numericKinds(n) {
+			num, ok := numberOfValue(val)
+			assert.True(t, ok, fmt.Sprintf("%T %v", val, val))
+			assert.Equal(t, 0, num.cmp(number{kind: intNumber, i: n}), fmt.Sprintf("%T %v", val, val))
+			assert.True(t, num.isWhole(), fmt.Sprintf("%T %v", val, val))
+
+			ptr := &val
+			num, ok = numberOfValue(ptr)
+			assert.True(t, ok)
+			assert.Equal(t, 0, num.cmp(number{kind: intNumber, i: n}), fmt.Sprintf("*%T %v", val, val))
+		}
+	}
+
+	for _, val := range []interface{}{"", "abc", "1,5", "0x10", math.NaN(), "NaN", true, nil, []int{1}, (*int)(nil)} {
+		_, ok := numberOfValue(val)
+		assert.False(t, ok, fmt.Sprintf("%T %v", val, val))
+	}
+
+	num, ok := numberOfValue(" 18446744073709551615 ")
+	assert.True(t, ok)
+	assert.Equal(t, number{kind: uintNumber, u: math.MaxUint64}, num)
+
+	num, ok = numberOfValue("-2.5e3")
+	assert.True(t, ok)
+	assert.Equal(t, number{kind: floatNumber, f: -2500}, num)
+}
+
+func TestNumberCmp(t *testing.T) {
+	t.Parallel()
+
+	// in increasing order; equal values are in the same group
+	groups := [][]number{
+		{{kind: floatNumber, f: math.Inf(-1)}},
+		{{kind: floatNumber, f: -twoPow63 * 2}},
+		{{kind: intNumber, i: math.MinInt64}, {kind: floatNumber, f: -twoPow63}},
+		{{kind: intNumber, i: math.MinInt64 + 1}},
+		{{kind: intNumber, i: -1}, {kind: floatNumber, f: -1}},
+		{{kind: floatNumber, f: -0.5}},
+		{{kind: intNumber}, {kind: uintNumber}, {kind: floatNumber}, {kind: floatNumber, f: math.Copysign(0, -1)}},
+		{{kind: floatNumber, f: 0.5}},
+		{{kind: intNumber, i: 1}, {kind: uintNumber, u: 1}, {kind: floatNumber, f: 1}},
+		{{kind: floatNumber, f: 1.5}},
+		{{kind: intNumber, i: 1 << 53}, {kind: uintNumber, u: 1 << 53}, {kind: floatNumber, f: 1 << 53}},
+		{{kind: intNumber, i: 1<<53 + 1}, {kind: uintNumber, u: 1<<53 + 1}},
+		{{kind: intNumber, i: math.MaxInt64 - 1}},
+		{{kind: intNumber, i: math.MaxInt64}, {kind: uintNumber, u: math.MaxInt64}},
+		{{kind: uintNumber, u: 1 << 63}, {kind: floatNumber, f: twoPow63}},
+		{{kind: uintNumber, u: math.MaxUint64 - 1}},
+		{{kind: uintNumber, u: math.MaxUint64}},
+		{{kind: floatNumber, f: twoPow64}},
+		{{kind: floatNumber, f: math.MaxFloat64}},
+		{{kind: floatNumber, f: math.Inf(1)}},
+	}
+
+	for i, a := range groups {
+		for j, b := range groups {
+			for _, x := range a {
+				for _, y := range b {
+					assert.Equal(t, compareInt(int64(i), int64(j)), x.cmp(y), fmt.Sprintf("%+v cmp %+v", x, y))
+				}
+			}
+		}
+	}
+}
+
+func TestNumberSignAndWhole(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param interface{}
+		sign  int
+		whole bool
+	}{
+		{int8(-3), -1, true},
+		{int64(math.MinInt64), -1, true},
+		{uint64(math.MaxUint64), 1, true},
+		{uint8(0), 0, true},
+		{float32(-0.25), -1, false},
+		{2.0, 1, true},
+		{1e300, 1, true},
+		{math.Inf(1), 1, false},
+		{"-7", -1, true},
+		{"0.0", 0, true},
+		{"3.5", 1, false},
+	}
+	for _, test := range tests {
+		n, ok := numberOfValue(test.param)
+		assert.True(t, ok)
+		assert.Equal(t, test.sign, n.sign(), fmt.Sprintf("%T %v", test.param, test.param))
+		assert.Equal(t, test.whole, n.isWhole(), fmt.Sprintf("%T %v", test.param, test.param))
+	}
+}
+
+func TestIsPortKinds(t *testing.T) {
+	t.Parallel()
+
+	for n, expected := range map[int64]bool{-1: false, 0: false, 1: true, 80: true, 65535: true, 65536: false} {
+		for _, val := range numericKinds(n) {
+			assert.Equal(t, expected, IsPort(val), fmt.Sprintf("%T %v", val, val))
+		}
+	}
+	assert.False(t, IsPort(80.5))
+	assert.False(t, IsPort(true))
+}
+
+func TestBetweenKinds(t *testing.T) {
+	t.Parallel()
+
+	// zero is checked like any number; only empty strings are left to required
+	for n, expected := range map[int64]bool{-6: false, -5: true, 0: true, 7: true, 8: false} {
+		for _, val := range numericKinds(n) {
+			if _, ok := val.(string); ok {
+				continue
+			}
+			assert.Equal(t, expected, Between(val, "-5", "7"), fmt.Sprintf("%T %v", val, val))
+		}
+	}
+	for n, expected := range map[int64]bool{0: false, 1: true, 5: true, 6: false} {
+		for _, val := range numericKinds(n) {
+			if _, ok := val.(string); ok {
+				continue
+			}
+			assert.Equal(t, expected, Between(val, "1", "5"), fmt.Sprintf("%T %v", val, val))
+		}
+	}
+
+	// bounds past the range of float64 integers or of the value kind
+	assert.True(t, Between(uint64(math.MaxUint64), "0", "18446744073709551615"))
+	assert.False(t, Between(uint64(math.MaxUint64), "0", "18446744073709551614"))
+	assert.True(t, Between(int64(math.MinInt64), "-9223372036854775808", "-1"))
+	assert.False(t, Between(int64(1<<53+1), "0", "9007199254740992"))
+	assert.True(t, Between(uint8(200), "-1", "1e20"))
+	assert.True(t, Between(float32(0.5), "0.25", "0.75"))
+	assert.False(t, Between(0.8, "0.25", "0.75"))
+
+	// strings are checked by length
+	assert.True(t, Between("", "1", "5"))
+	assert.True(t, Between("12345678", "8", "8"))
+	assert.False(t, Between("1", "2", "5"))
+
+	assert.False(t, Between(3, "1"))
+	assert.False(t, Between(3, "one", "5"))
+	assert.False(t, Between(true, "0", "1"))
+}
+
+func TestBetweenRuleKinds(t *testing.T) {
+	t.Parallel()
+
+	type Limits struct {
+		I32 int32   `json:"i32" valid:"between(1,5)"`
+		U16 uint16  `json:"u16" valid:"between(1,5)"`
+		I64 int64   `json:"i64" valid:"between(1,5)"`
+		U64 uint64  `json:"u64" valid:"between(1,5)"`
+		F32 float32 `json:"f32" valid:"between(1,5)"`
+		P   *int64  `json:"p" valid:"port"`
+	}
+
+	port := int64(8080)
+	bag, err := ValidateStruct(Limits{I32: 1, U16: 2, I64: 3, U64: 4, F32: 5, P: &port})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	port = 70000
+	bag, err = ValidateStruct(Limits{I32: 9, U16: 9, I64: math.MaxInt64, U64: math.MaxUint64, F32: 5.5, P: &port})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"i32:between", "u16:between", "i64:between", "u64:between", "f32:between", "p:port"},
+		embeddedErrorPaths(bag))
+}
+
+func TestNumberRulesKinds(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		n                                                            int64
+		positive, nonnegative, negative, nonpositive, whole, natural bool
+	}{
+		{-3, false, false, true, true, true, false},
+		{0, false, true, false, true, true, false},
+		{7, true, true, false, false, true, true},
+	}
+	for _, test := range tests {
+		for _, val := range numericKinds(test.n) {
+			assert.Equal(t, test.positive, isPositiveNumber(val), fmt.Sprintf("positive %T %v", val, val))
+			assert.Equal(t, test.nonnegative, isNonNegativeNumber(val), fmt.Sprintf("nonnegative %T %v", val, val))
+			assert.Equal(t, test.negative, isNegativeNumber(val), fmt.Sprintf("negative %T %v", val, val))
+			assert.Equal(t, test.nonpositive, isNonPositiveNumber(val), fmt.Sprintf("nonpositive %T %v", val, val))
+			assert.Equal(t, test.whole, isWholeNumber(val), fmt.Sprintf("whole %T %v", val, val))
+			assert.Equal(t, test.natural, isNaturalNumber(val), fmt.Sprintf("natural %T %v", val, val))
+		}
+	}
+
+	for _, rule := range []func(interface{}, ...interface{}) bool{isPositiveNumber, isNonNegativeNumber, isNegativeNumber,
+		isNonPositiveNumber, isWholeNumber, isNaturalNumber} {
+		assert.True(t, rule(""))
+		assert.False(t, rule("abc"))
+		assert.False(t, rule(true))
+		assert.False(t, rule(math.NaN()))
+	}
+	assert.False(t, isWholeNumber(2.5))
+	assert.False(t, isWholeNumber("2.5"))
+	assert.False(t, isWholeNumber(math.Inf(1)))
+	assert.True(t, isPositiveNumber(float32(0.001)))
+	assert.False(t, isNonNegativeNumber(-0.001))
+	assert.True(t, isNegativeNumber("-0.001"))
+	assert.False(t, isNonPositiveNumber(float32(0.001)))
+	assert.False(t, isNaturalNumber(2.5))
+	assert.True(t, isNaturalNumber("12"))
+}
+
+func TestDivisibleByAndMultipleOf(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	for n, expected := range map[int64]bool{-10: true, -3: false, 0: true, 5: true, 7: false, 120: true} {
+		for _, val := range numericKinds(n) {
+			valid, err := isDivisibleBy(ctx, val, "5")
+			assert.Nil(t, err)
+			assert.Equal(t, expected, valid, fmt.Sprintf("divisibleby %T %v", val, val))
+		}
+	}
+
+	var tests = []struct {
+		value    interface{}
+		step     string
+		expected bool
+	}{
+		{uint64(math.MaxUint64), "5", true},
+		{uint64(math.MaxUint64 - 1), "5", false},
+		{int64(math.MinInt64), "2", true},
+		{"18446744073709551615", "3", true},
+		{10.0, "5", true},
+		{10.5, "5", false},
+		{0.07, "0.01", true},
+		{float32(0.07), "0.01", true},
+		{19.99, "0.01", true},
+		{19.995, "0.01", false},
+		{"19.99", "0.01", true},
+		{0.3, "0.1", true},
+		{7, "0.25", true},
+		{7.1, "0.25", false},
+		{7.75, "0.25", true},
+		{int8(-9), "1.5", true},
+		{math.Inf(1), "0.5", false},
+		{"", "0.01", true},
+		{"abc", "0.01", false},
+	}
+	for _, test := range tests {
+		valid, err := isMultipleOf(ctx, test.value, test.step)
+		assert.Nil(t, err)
+		if test.step == "5" || test.step == "2" || test.step == "3" {
+			divisible, err := isDivisibleBy(ctx, test.value, test.step)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, divisible, fmt.Sprintf("divisibleby %T %v", test.value, test.value))
+		}
+		assert.Equal(t, test.expected, valid, fmt.Sprintf("multipleof %T %v", test.value, test.value))
+	}
+
+	for _, step := range []string{"0", "-5", "abc", "Inf"} {
+		_, err := isMultipleOf(ctx, 10, step)
+		assert.NotNil(t, err, step)
+	}
+	_, err := isDivisibleBy(ctx, 10, "2.5")
+	assert.NotNil(t, err)
+	_, err = isDivisibleBy(ctx, 10)
+	assert.NotNil(t, err)
+}
+
+func TestNumberRules(t *testing.T) {
+	t.Parallel()
+
+	type Order struct {
+		Quantity uint16  `json:"quantity" valid:"positive|whole|divisibleby(6)"`
+		Discount float64 `json:"discount" valid:"nonnegative"`
+		Price    float32 `json:"price" valid:"positive|multipleof(0.01)"`
+		Weight   string  `json:"weight" valid:"positive|multipleof(0.5)"`
+	}
+
+	bag, err := ValidateStruct(Order{Quantity: 12, Discount: 0, Price: 19.99, Weight: "2.5"})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	bag, err = ValidateStruct(Order{Quantity: 0, Discount: -1, Price: 19.999, Weight: "-2.25"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"quantity:positive", "discount:nonnegative", "price:multipleof", "weight:positive", "weight:multipleof"},
+		embeddedErrorPaths(bag))
+	assert.Equal(t, "Price must be a multiple of 0.01", bag.Errors()[2].Message())
+	assert.Equal(t, "Discount doit être un nombre non négatif", bag.Errors()[1].Localize("fr").Message())
+
+	bag, err = ValidateStruct(Order{Quantity: 8, Price: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"quantity:divisibleby"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "Quantity must be a whole number divisible by 6", bag.FirstErrorMessage())
+
+	fields, err := HTMLFields(Order{})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"type": "number", "min": "1", "step": "6"}, fields[0].Attributes)
+	assert.Equal(t, map[string]string{"type": "number", "step": "0.01"}, fields[2].Attributes)
+}
+
+func TestSignRules(t *testing.T) {
+	t.Parallel()
+
+	type Adjustment struct {
+		Refund int     `json:"refund" valid:"negative"`
+		Credit float64 `json:"credit" valid:"nonpositive"`
+		Seats  string  `json:"seats" valid:"natural"`
+	}
+
+	bag, err := ValidateStruct(Adjustment{Refund: -5, Credit: 0, Seats: "3"})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	bag, err = ValidateStruct(Adjustment{Refund: 0, Credit: 0.5, Seats: "0"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"refund:negative", "credit:nonpositive", "seats:natural"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "Refund must be a negative number", bag.Errors()[0].Message())
+	assert.Equal(t, "Credit doit être un nombre non positif", bag.Errors()[1].Localize("fr").Message())
+	assert.Equal(t, "Seats must be a whole number greater than zero", bag.Errors()[2].Message())
+
+	fields, err := HTMLFields(Adjustment{})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"type": "number", "max": "-1"}, fields[0].Attributes)
+	assert.Equal(t, map[string]string{"type": "number", "max": "0"}, fields[1].Attributes)
+	assert.Equal(t, map[string]string{"type": "number", "min": "1", "step": "1"}, fields[2].Attributes)
+}
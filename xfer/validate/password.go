@@ -0,0 +1,318 @@
+package validate
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// DefaultPasswordPolicy is the policy of the password rule without a param
+const DefaultPasswordPolicy = "default"
+
+// minForbiddenLength is the length under which forbidden values (a two letter username) are ignored, as they'd
+// forbid too many passwords
+const minForbiddenLength = 3
+
+// Password failure reasons. Their messages are password.<reason>.message.
+const (
+	PasswordTooShort       = "minlength"
+	PasswordTooLong        = "maxlength"
+	PasswordNoLower        = "lower"
+	PasswordNoUpper        = "upper"
+	PasswordNoDigit        = "digit"
+	PasswordNoSymbol       = "symbol"
+	PasswordTooFewClasses  = "classes"
+	PasswordTooPredictable = "entropy"
+	PasswordForbidden      = "forbidden"
+	PasswordBreached       = "breached"
+)
+
+// PasswordPolicy describes the passwords accepted by the password rule. Zero values disable a check.
+type PasswordPolicy struct {
+	// MinLength and MaxLength count characters, not bytes
+	MinLength int
+	MaxLength int
+
+	RequireLower  bool
+	RequireUpper  bool
+	RequireDigit  bool
+	RequireSymbol bool
+
+	// MinClasses is the number of character classes (lower case, upper case, digits, symbols) the password must
+	// mix, whichever they are
+	MinClasses int
+
+	// MinEntropy is the minimum strength in bits, as estimated by PasswordEntropyBits
+	MinEntropy float64
+
+	// Forbidden are words passwords must not contain, ignoring case, e.g. the site name. The password rule adds the
+	// values of the fields given as params, e.g. password(default,Username,Email); the local part of email
+	// addresses is used.
+	Forbidden []string
+
+	// Breached checks passwords against known breaches, e.g. with BreachedPasswordFiles
+	Breached BreachedPasswords
+}
+
+// BreachedPasswords tells if a password appeared in a data breach
+type BreachedPasswords interface {
+	IsBreached(password string) (bool, error)
+}
+
+// BreachedPasswordFiles checks passwords against a local copy of a k-anonymity password range set, such as the
+// Pwned Passwords one: a directory with one file per 5 hex digit SHA-1 prefix (00000 to FFFFF), each holding the
+// SUFFIX:COUNT lines of the hashes starting with the prefix. Only the file of the password hash prefix is read.
+type BreachedPasswordFiles struct {
+	Dir string
+
+	// MinCount ignores hashes seen in fewer breaches. 0 and 1 count any breach.
+	MinCount int
+}
+
+// IsBreached implements BreachedPasswords. A missing prefix file means no breach.
+func (b BreachedPasswordFiles) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	f, err := os.Open(filepath.Join(b.Dir, hash[:5]))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		idx := strings.Index(line, ":")
+		if idx < 0 || !strings.EqualFold(line[:idx], hash[5:]) {
+			continue
+		}
+
+		count, err := strconv.Atoi(line[idx+1:])
+		if err != nil {
+			return false, fmt.Errorf("Invalid breach count in %s: %q", f.Name(), line)
+		}
+		return count >= b.MinCount, nil
+	}
+
+	return false, scanner.Err()
+}
+
+var passwordPolicies = struct {
+	sync.RWMutex
+	policies map[string]PasswordPolicy
+}{
+	policies: map[string]PasswordPolicy{
+		DefaultPasswordPolicy: {MinLength: 10, MaxLength: 60},
+		"strong":              {MinLength: 12, MaxLength: 128, MinClasses: 3, MinEntropy: 60},
+	},
+}
+
+// RegisterPasswordPolicy adds a policy for the password(name) rule, or replaces the one with the same name
+func RegisterPasswordPolicy(name string, policy PasswordPolicy) error {
+	if len(name) == 0 || strings.ContainsAny(name, validatorSeparator+paramSeparator+paramCloseToken) {
+		return fmt.Errorf("%q is not a valid password policy name", name)
+	}
+	if policy.MaxLength > 0 && policy.MaxLength < policy.MinLength {
+		return fmt.Errorf("The max length of password policy %s is less than its min length", name)
+	}
+
+	passwordPolicies.Lock()
+	defer passwordPolicies.Unlock()
+	passwordPolicies.policies[name] = policy
+	return nil
+}
+
+// GetPasswordPolicy returns a registered password policy
+func GetPasswordPolicy(name string) (PasswordPolicy, bool) {
+	passwordPolicies.RLock()
+	defer passwordPolicies.RUnlock()
+	policy, ok := passwordPolicies.policies[name]
+	return policy, ok
+}
+
+// passwordClasses returns which character classes the password uses. Letters without case count as lower case.
+func passwordClasses(password string) (lower, upper, digit, symbol bool) {
+	for _, c := range password {
+		switch {
+		case unicode.IsUpper(c):
+			upper = true
+		case unicode.IsLetter(c):
+			lower = true
+		case unicode.IsDigit(c):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	return
+}
+
+// PasswordEntropyBits estimates the strength of a password in bits: its length times the bits of a character
+// drawn from the classes it uses. Characters repeating the previous one or continuing a sequence (abc, 321) don't
+// add to the length, so aaaaaaaa and 12345678 are as weak as a single character.
+func PasswordEntropyBits(password string) float64 {
+	pool := 0
+	lower, upper, digit, symbol := passwordClasses(password)
+	if lower {
+		pool += 26
+	}
+	if upper {
+		pool += 26
+	}
+	if digit {
+		pool += 10
+	}
+	if symbol {
+		pool += 33
+	}
+	if pool == 0 {
+		return 0
+	}
+
+	length := 0
+	var prev rune = -1
+	for _, c := range password {
+		if c != prev && c != prev+1 && c != prev-1 {
+			length++
+		}
+		prev = c
+	}
+
+	return float64(length) * math.Log2(float64(pool))
+}
+
+// CheckPassword checks a password against a policy and returns the reasons it fails, or nothing if it's accepted.
+// Forbidden are values the password must not contain on top of the policy ones, such as the username; the local
+// part of email addresses is used. An error means the breach check failed.
+func CheckPassword(password string, policy PasswordPolicy, forbidden ...string) ([]Reason, error) {
+	reasons := make([]Reason, 0)
+	fail := func(key string, values map[string]interface{}) {
+		reasons = append(reasons, Reason{Key: key, Values: values})
+	}
+
+	length := len([]rune(password))
+	if policy.MinLength > 0 && length < policy.MinLength {
+		fail(PasswordTooShort, map[string]interface{}{"min": policy.MinLength})
+	}
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		fail(PasswordTooLong, map[string]interface{}{"max": policy.MaxLength})
+	}
+
+	lower, upper, digit, symbol := passwordClasses(password)
+	if policy.RequireLower && !lower {
+		fail(PasswordNoLower, nil)
+	}
+	if policy.RequireUpper && !upper {
+		fail(PasswordNoUpper, nil)
+	}
+	if policy.RequireDigit && !digit {
+		fail(PasswordNoDigit, nil)
+	}
+	if policy.RequireSymbol && !symbol {
+		fail(PasswordNoSymbol, nil)
+	}
+	classes := 0
+	for _, used := range []bool{lower, upper, digit, symbol} {
+		if used {
+			classes++
+		}
+	}
+	if classes < policy.MinClasses {
+		fail(PasswordTooFewClasses, map[string]interface{}{"min": policy.MinClasses})
+	}
+
+	if policy.MinEntropy > 0 && PasswordEntropyBits(password) < policy.MinEntropy {
+		fail(PasswordTooPredictable, map[string]interface{}{"min": policy.MinEntropy})
+	}
+
+	if containsForbidden(password, forbidden) || containsForbidden(password, policy.Forbidden) {
+		fail(PasswordForbidden, nil)
+	}
+
+	if policy.Breached != nil {
+		breached, err := policy.Breached.IsBreached(password)
+		if err != nil {
+			return reasons, err
+		}
+		if breached {
+			fail(PasswordBreached, nil)
+		}
+	}
+
+	return reasons, nil
+}
+
+// containsForbidden is true if the password contains one of the forbidden values, ignoring case
+func containsForbidden(password string, forbidden []string) bool {
+	password = strings.ToLower(password)
+	for _, word := range forbidden {
+		if a, err := ParseEmail(word); err == nil {
+			word = a.Local
+		}
+		word = strings.ToLower(strings.TrimSpace(word))
+		if len([]rune(word)) >= minForbiddenLength && strings.Contains(password, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// PasswordReasonsMessage renders the reasons returned by CheckPassword in locale, e.g. "use at least 10 characters,
+// add a digit"
+func PasswordReasonsMessage(reasons []Reason, locale string) string {
+	return renderReasons("password", reasons, locale)
+}
+
+// passwordParams returns the policy named by the first password rule param (DefaultPasswordPolicy without params)
+// and the forbidden values given by the other params
+func passwordParams(params []interface{}) (PasswordPolicy, []string, error) {
+	name := DefaultPasswordPolicy
+	if len(params) > 0 {
+		name = strings.TrimSpace(toString(params[0]))
+		params = params[1:]
+	}
+
+	policy, ok := GetPasswordPolicy(name)
+	if !ok {
+		return policy, nil, fmt.Errorf("Unknown password policy %s", name)
+	}
+
+	forbidden := make([]string, 0, len(params))
+	for _, param := range params {
+		if param != nil {
+			forbidden = append(forbidden, toString(param))
+		}
+	}
+	return policy, forbidden, nil
+}
+
+// passwordReasons is the password validator, e.g. password(strong,Username,Email). It returns the reasons the
+// password fails the policy. Empty values are ignored; use required for them. It's a slow validator, as the policy
+// can check passwords against breaches.
+func passwordReasons(ctx context.Context, val interface{}, params ...interface{}) ([]Reason, error) {
+	str, ok := val.(string)
+	if !ok {
+		return nil, fmt.Errorf("password only validates strings; got %T", val)
+	}
+	if len(str) == 0 {
+		return nil, nil
+	}
+
+	policy, forbidden, err := passwordParams(params)
+	if err != nil {
+		return nil, err
+	}
+	return CheckPassword(str, policy, forbidden...)
+}
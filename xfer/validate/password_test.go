@@ -0,0 +1,220 @@
+package validate
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func reasonKeys(reasons []Reason) []string {
+	keys := make([]string, len(reasons))
+	for i, reason := range reasons {
+		keys[i] = reason.Key
+	}
+	return keys
+}
+
+func TestCheckPassword(t *testing.T) {
+	t.Parallel()
+
+	policy := PasswordPolicy{
+		MinLength:     8,
+		MaxLength:     20,
+		RequireLower:  true,
+		RequireUpper:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+		Forbidden:     []string{"genesis"},
+	}
+
+	var tests = []struct {
+		password  string
+		forbidden []string
+		expected  []string
+	}{
+		{"Tr0ub4dor&3", nil, []string{}},
+		{"tr0ub4dor&3", nil, []string{PasswordNoUpper}},
+		{"TR0UB4DOR&3", nil, []string{PasswordNoLower}},
+		{"Troubador&", nil, []string{PasswordNoDigit}},
+		{"Tr0ub4dor3", nil, []string{PasswordNoSymbol}},
+		{"Tr0&b", nil, []string{PasswordTooShort}},
+		{"Tr0ub4dor&3Tr0ub4dor&3", nil, []string{PasswordTooLong}},
+		{"Ünïcödé&3ß", nil, []string{}},
+		{"My-Genesis-1", nil, []string{PasswordForbidden}},
+		{"Ann.Smith&3", []string{"ann.smith@example.com"}, []string{PasswordForbidden}},
+		{"Anne&Co3xy", []string{"al", "bob"}, []string{}},
+		{"abc", nil, []string{PasswordTooShort, PasswordNoUpper, PasswordNoDigit, PasswordNoSymbol}},
+	}
+	for _, test := range tests {
+		reasons, err := CheckPassword(test.password, policy, test.forbidden...)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, reasonKeys(reasons), test.password)
+	}
+
+	reasons, err := CheckPassword("aaaaaaaaaaaaaaaa", PasswordPolicy{MinClasses: 2, MinEntropy: 40})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{PasswordTooFewClasses, PasswordTooPredictable}, reasonKeys(reasons))
+}
+
+func TestPasswordEntropyBits(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0.0, PasswordEntropyBits(""))
+	assert.InDelta(t, math.Log2(26), PasswordEntropyBits("aaaaaaaa"), 0.001)
+	assert.InDelta(t, math.Log2(10), PasswordEntropyBits("12345678"), 0.001)
+	assert.InDelta(t, math.Log2(10), PasswordEntropyBits("87654321"), 0.001)
+	assert.InDelta(t, 4*math.Log2(36), PasswordEntropyBits("a1b2"), 0.001)
+	assert.InDelta(t, 11*math.Log2(95), PasswordEntropyBits("Tr0ub4dor&3"), 0.001)
+}
+
+// writeBreachedPasswords writes the prefix file of each password, with its count, to a new directory
+func writeBreachedPasswords(t *testing.T, counts map[string]int) string {
+	dir, err := ioutil.TempDir("", "breached")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for password, count := range counts {
+		sum := sha1.Sum([]byte(password))
+		hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+		f, err := os.OpenFile(filepath.Join(dir, hash[:5]), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// a neighbouring hash with the same prefix
+		_, err = f.WriteString("0000000000000000000000000000000000F:1\r\n" + hash[5:] + ":" + string(rune('0'+count)) + "\r\n")
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestBreachedPasswordFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := writeBreachedPasswords(t, map[string]int{"password1": 9, "rarely-used": 1})
+	defer os.RemoveAll(dir)
+
+	files := BreachedPasswordFiles{Dir: dir}
+	for password, expected := range map[string]bool{"password1": true, "rarely-used": true, "Tr0ub4dor&3": false} {
+		breached, err := files.IsBreached(password)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, breached, password)
+	}
+
+	files.MinCount = 2
+	breached, err := files.IsBreached("rarely-used")
+	assert.Nil(t, err)
+	assert.False(t, breached)
+
+	_, err = BreachedPasswordFiles{Dir: filepath.Join(dir, "password1")}.IsBreached("password1")
+	assert.Nil(t, err)
+}
+
+func TestPasswordRule(t *testing.T) {
+	t.Parallel()
+
+	dir := writeBreachedPasswords(t, map[string]int{"password123": 9})
+	defer os.RemoveAll(dir)
+	assert.Nil(t, RegisterPasswordPolicy("testbreached", PasswordPolicy{MinLength: 8, Breached: BreachedPasswordFiles{Dir: dir}}))
+	assert.NotNil(t, RegisterPasswordPolicy("bad,name", PasswordPolicy{}))
+	assert.NotNil(t, RegisterPasswordPolicy("inverted", PasswordPolicy{MinLength: 10, MaxLength: 8}))
+
+	type Signup struct {
+		Username string `json:"username"`
+		Email    string `json:"email"`
+		Password string `json:"password" valid:"password(strong,Username,Email)|redact=true"`
+		Pin      string `json:"pin" valid:"password(testbreached)"`
+	}
+
+	bag, err := ValidateStruct(Signup{Username: "ann", Email: "ann.smith@example.com", Password: "Tr0ub4dor&3x", Pin: "Tr0ub4dor"})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	bag, err = ValidateStruct(Signup{Username: "ann", Email: "ann.smith@example.com", Password: "annsmith", Pin: "password123"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"password:password", "pin:password"}, embeddedErrorPaths(bag))
+
+	e := bag.Errors()[0]
+	assert.Equal(t, []string{PasswordTooShort, PasswordTooFewClasses, PasswordTooPredictable, PasswordForbidden}, e.Reasons)
+	assert.Equal(t, "Password is too weak: use at least 12 characters, mix at least 3 of lower case letters, upper case "+
+		"letters, digits and symbols, make it less predictable, don't use your name or email address", e.Message())
+	assert.Equal(t, RedactedValue, e.Value)
+	assert.Equal(t, "Password ist zu schwach: mindestens 12 Zeichen verwenden, mindestens 3 Zeichenarten aus Klein- und "+
+		"Großbuchstaben, Ziffern und Sonderzeichen mischen, weniger vorhersehbar wählen, weder Namen noch E-Mail-Adresse "+
+		"verwenden", e.Localize("de").Message())
+
+	e = bag.Errors()[1]
+	assert.Equal(t, "Pin is too weak: choose one that hasn't appeared in a data breach", e.Message())
+	js, err := json.Marshal(e)
+	assert.Nil(t, err)
+	assert.Contains(t, string(js), `"reasons":["breached"]`)
+
+	type Unknown struct {
+		Password string `valid:"password(nosuchpolicy)"`
+	}
+	_, err = ValidateStruct(Unknown{Password: "Tr0ub4dor&3"})
+	assert.NotNil(t, err)
+}
+
+// countingBreaches counts the breach checks, and fails them with err
+type countingBreaches struct {
+	calls *int32
+	err   error
+}
+
+func (b countingBreaches) IsBreached(password string) (bool, error) {
+	atomic.AddInt32(b.calls, 1)
+	return true, b.err
+}
+
+func TestPasswordBreachIsCheckedOnce(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	assert.Nil(t, RegisterPasswordPolicy("testcounted", PasswordPolicy{Breached: countingBreaches{calls: &calls}}))
+	assert.Nil(t, RegisterPasswordPolicy("testunavailable", PasswordPolicy{Breached: countingBreaches{calls: new(int32), err: errors.New("unavailable")}}))
+
+	bag, err := ValidateVar("password123", "password(testcounted)")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{PasswordBreached}, bag.Errors()[0].Reasons)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	_, err = ValidateVar("password123", "password(testunavailable)")
+	assert.NotNil(t, err)
+}
+
+func TestPasswordIsRedacted(t *testing.T) {
+	t.Parallel()
+
+	type Signup struct {
+		Email    string `json:"email" valid:"email->{value} is taken by {other.Password}"`
+		Password string `json:"password" valid:"alpha|password"`
+	}
+
+	bag, err := ValidateStruct(Signup{Email: "nope", Password: "hunter2!"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"email:email", "password:alpha", "password:password"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "nope is taken by "+RedactedValue, bag.FirstErrorMessage())
+	for _, e := range bag.Errors()[1:] {
+		assert.True(t, e.Redacted)
+		assert.Equal(t, RedactedValue, e.Value)
+	}
+
+	for _, problem := range []interface{}{bag, bag.ProblemWithValues()} {
+		body, err := json.Marshal(problem)
+		assert.Nil(t, err)
+		assert.NotContains(t, string(body), "hunter2")
+	}
+}
@@ -0,0 +1,299 @@
+package validate
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ibanLengths holds the IBAN length of each country in the SWIFT IBAN registry. Kosovo (XK) uses IBANs but isn't in
+// ISO3166List, so it's left out.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BI": 27,
+	"BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28,
+	"EE": 20, "EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23,
+	"GL": 18, "GR": 27, "GT": 28, "HN": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26,
+	"IT": 27, "JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21,
+	"LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27, "MT": 31, "MU": 30, "NI": 28,
+	"NL": 18, "NO": 15, "OM": 23, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22,
+	"RU": 33, "SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25,
+	"SV": 28, "TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "YE": 30,
+}
+
+var (
+	rxIBAN = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]+$`)
+	rxBIC  = regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+)
+
+// IsIBAN check if the string is an IBAN with a valid country, length and mod-97 check digits. Spaces between
+// groups are allowed. Empty string is valid.
+func IsIBAN(str string, params ...interface{}) bool {
+	if IsNull(str) {
+		return true
+	}
+
+	iban := strings.ToUpper(strings.Replace(str, " ", "", -1))
+	if !rxIBAN.MatchString(iban) || !IsISO3166Alpha2(iban[:2]) {
+		return false
+	}
+	if length, ok := ibanLengths[iban[:2]]; !ok || len(iban) != length {
+		return false
+	}
+
+	return mod97(iban[4:]+iban[:4]) == 1
+}
+
+// mod97 returns the ISO 7064 mod 97-10 remainder of an alphanumeric string, with letters counting as 10-35
+func mod97(str string) int {
+	r := 0
+	for _, c := range str {
+		switch {
+		case c >= '0' && c <= '9':
+			r = (r*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			r = (r*100 + int(c-'A'+10)) % 97
+		default:
+			return -1
+		}
+	}
+	return r
+}
+
+// IsBIC check if the string is a SWIFT/BIC code (8 or 11 characters) with a valid country. Empty string is valid.
+func IsBIC(str string, params ...interface{}) bool {
+	if IsNull(str) {
+		return true
+	}
+
+	bic := strings.ToUpper(str)
+	return rxBIC.MatchString(bic) && IsISO3166Alpha2(bic[4:6])
+}
+
+// vatFormat describes the VAT numbers of a country: the number after the prefix must match pattern, and checksum
+// if there is one
+type vatFormat struct {
+	country  string
+	pattern  *regexp.Regexp
+	checksum func(number string) bool
+}
+
+// vatFormats holds the VAT number formats of EU member states (and Northern Ireland), keyed by VAT prefix. Greece
+// uses EL instead of its ISO code.
+var vatFormats = map[string]vatFormat{
+	"AT": {"AT", regexp.MustCompile(`^U[0-9]{8}$`), vatChecksumAT},
+	"BE": {"BE", regexp.MustCompile(`^[01][0-9]{9}$`), vatChecksumBE},
+	"BG": {"BG", regexp.MustCompile(`^[0-9]{9,10}$`), nil},
+	"CY": {"CY", regexp.MustCompile(`^[0-9]{8}[A-Z]$`), nil},
+	"CZ": {"CZ", regexp.MustCompile(`^[0-9]{8,10}$`), nil},
+	"DE": {"DE", regexp.MustCompile(`^[0-9]{9}$`), iso7064Mod1110},
+	"DK": {"DK", regexp.MustCompile(`^[0-9]{8}$`), weightedMod11([]int{2, 7, 6, 5, 4, 3, 2, 1})},
+	"EE": {"EE", regexp.MustCompile(`^[0-9]{9}$`), vatChecksumEE},
+	"EL": {"GR", regexp.MustCompile(`^[0-9]{9}$`), vatChecksumEL},
+	"ES": {"ES", regexp.MustCompile(`^[0-9A-Z][0-9]{7}[0-9A-Z]$`), nil},
+	"FI": {"FI", regexp.MustCompile(`^[0-9]{8}$`), vatChecksumFI},
+	"FR": {"FR", regexp.MustCompile(`^[0-9A-Z]{2}[0-9]{9}$`), vatChecksumFR},
+	"HR": {"HR", regexp.MustCompile(`^[0-9]{11}$`), iso7064Mod1110},
+	"HU": {"HU", regexp.MustCompile(`^[0-9]{8}$`), vatChecksumHU},
+	"IE": {"IE", regexp.MustCompile(`^([0-9]{7}[A-W][A-IW]?|[0-9][A-Z+*][0-9]{5}[A-W])$`), nil},
+	"IT": {"IT", regexp.MustCompile(`^[0-9]{11}$`), luhn},
+	"LT": {"LT", regexp.MustCompile(`^([0-9]{9}|[0-9]{12})$`), nil},
+	"LU": {"LU", regexp.MustCompile(`^[0-9]{8}$`), vatChecksumLU},
+	"LV": {"LV", regexp.MustCompile(`^[0-9]{11}$`), nil},
+	"MT": {"MT", regexp.MustCompile(`^[0-9]{8}$`), nil},
+	"NL": {"NL", regexp.MustCompile(`^[0-9]{9}B[0-9]{2}$`), vatChecksumNL},
+	"PL": {"PL", regexp.MustCompile(`^[0-9]{10}$`), vatChecksumPL},
+	"PT": {"PT", regexp.MustCompile(`^[0-9]{9}$`), vatChecksumPT},
+	"RO": {"RO", regexp.MustCompile(`^[1-9][0-9]{1,9}$`), nil},
+	"SE": {"SE", regexp.MustCompile(`^[0-9]{10}01$`), func(number string) bool { return luhn(number[:10]) }},
+	"SI": {"SI", regexp.MustCompile(`^[1-9][0-9]{7}$`), vatChecksumSI},
+	"SK": {"SK", regexp.MustCompile(`^[1-9][0-9]{9}$`), vatChecksumSK},
+	"XI": {"GB", regexp.MustCompile(`^([0-9]{9}|[0-9]{12}|GD[0-4][0-9]{2}|HA[5-9][0-9]{2})$`), nil},
+}
+
+// IsVATID check if the string is an EU VAT identification number: a country prefix, then the number in the
+// country's format, with its check digits where the country defines them. Spaces, dots and dashes are ignored. Empty
+// string is valid.
+func IsVATID(str string, params ...interface{}) bool {
+	if IsNull(str) {
+		return true
+	}
+
+	vat := strings.ToUpper(strings.NewReplacer(" ", "", ".", "", "-", "").Replace(str))
+	if len(vat) < 4 {
+		return false
+	}
+
+	format, ok := vatFormats[vat[:2]]
+	if !ok || !IsISO3166Alpha2(format.country) {
+		return false
+	}
+
+	number := vat[2:]
+	if !format.pattern.MatchString(number) {
+		return false
+	}
+	return format.checksum == nil || format.checksum(number)
+}
+
+// digitsAt returns the digit values of str, which must only hold ASCII digits
+func digitsAt(str string) []int {
+	digits := make([]int, len(str))
+	for i, c := range str {
+		digits[i] = int(c - '0')
+	}
+	return digits
+}
+
+// luhn checks the Luhn (mod 10) check digit, the last digit of number
+func luhn(number string) bool {
+	sum := 0
+	digits := digitsAt(number)
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := digits[i]
+		if (len(digits)-1-i)%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// iso7064Mod1110 checks the ISO 7064 mod 11,10 check digit, the last digit of number
+func iso7064Mod1110(number string) bool {
+	digits := digitsAt(number)
+	product := 10
+	for _, d := range digits[:len(digits)-1] {
+		sum := (d + product) % 10
+		if sum == 0 {
+			sum = 10
+		}
+		product = (2 * sum) % 11
+	}
+
+	check := 11 - product
+	if check == 10 {
+		check = 0
+	}
+	return check == digits[len(digits)-1]
+}
+
+// weightedSum returns the sum of the first len(weights) digits of number multiplied by the weights
+func weightedSum(number string, weights []int) int {
+	sum := 0
+	for i, d := range digitsAt(number[:len(weights)]) {
+		sum += d * weights[i]
+	}
+	return sum
+}
+
+// weightedMod11 returns a checksum that's valid when the weighted sum of all digits is a multiple of 11
+func weightedMod11(weights []int) func(string) bool {
+	return func(number string) bool {
+		return weightedSum(number, weights)%11 == 0
+	}
+}
+
+func vatChecksumAT(number string) bool {
+	digits := digitsAt(number[1:])
+	sum := 0
+	for i, d := range digits[:7] {
+		if i%2 == 1 {
+			d *= 2
+			d = d/10 + d%10
+		}
+		sum += d
+	}
+	return (10-(sum+4)%10)%10 == digits[7]
+}
+
+func vatChecksumBE(number string) bool {
+	n := atoiDigits(number[:8])
+	return 97-n%97 == atoiDigits(number[8:])
+}
+
+func vatChecksumEE(number string) bool {
+	sum := weightedSum(number, []int{3, 7, 1, 3, 7, 1, 3, 7})
+	return (10-sum%10)%10 == digitsAt(number)[8]
+}
+
+func vatChecksumEL(number string) bool {
+	sum := weightedSum(number, []int{256, 128, 64, 32, 16, 8, 4, 2})
+	return sum%11%10 == digitsAt(number)[8]
+}
+
+func vatChecksumFI(number string) bool {
+	r := weightedSum(number, []int{7, 9, 10, 5, 8, 4, 2}) % 11
+	if r == 1 {
+		return false
+	}
+	check := 0
+	if r > 0 {
+		check = 11 - r
+	}
+	return check == digitsAt(number)[7]
+}
+
+// vatChecksumFR checks the 2 digit key of French VAT numbers. Keys with letters (new style numbers) have no
+// published checksum.
+func vatChecksumFR(number string) bool {
+	if !IsNumeric(number[:2]) {
+		return true
+	}
+	return atoiDigits(number[:2]) == (12+3*(atoiDigits(number[2:])%97))%97
+}
+
+func vatChecksumHU(number string) bool {
+	sum := weightedSum(number, []int{9, 7, 3, 1, 9, 7, 3})
+	return (10-sum%10)%10 == digitsAt(number)[7]
+}
+
+func vatChecksumLU(number string) bool {
+	return atoiDigits(number[:6])%89 == atoiDigits(number[6:])
+}
+
+// vatChecksumNL accepts both the mod 97 check of numbers issued since 2020 and the mod 11 check of older numbers
+func vatChecksumNL(number string) bool {
+	if mod97("NL"+number) == 1 {
+		return true
+	}
+	return weightedSum(number, []int{9, 8, 7, 6, 5, 4, 3, 2})%11 == digitsAt(number[:9])[8]
+}
+
+func vatChecksumPL(number string) bool {
+	check := weightedSum(number, []int{6, 5, 7, 2, 3, 4, 5, 6, 7}) % 11
+	return check != 10 && check == digitsAt(number)[9]
+}
+
+func vatChecksumPT(number string) bool {
+	check := 11 - weightedSum(number, []int{9, 8, 7, 6, 5, 4, 3, 2})%11
+	if check > 9 {
+		check = 0
+	}
+	return check == digitsAt(number)[8]
+}
+
+func vatChecksumSI(number string) bool {
+	check := 11 - weightedSum(number, []int{8, 7, 6, 5, 4, 3, 2})%11
+	if check == 11 {
+		return false
+	}
+	if check == 10 {
+		check = 0
+	}
+	return check == digitsAt(number)[7]
+}
+
+func vatChecksumSK(number string) bool {
+	return atoiDigits(number)%11 == 0
+}
+
+// atoiDigits converts a string of at most 18 ASCII digits to an int
+func atoiDigits(str string) int {
+	n := 0
+	for _, d := range digitsAt(str) {
+		n = n*10 + d
+	}
+	return n
+}
@@ -0,0 +1,138 @@
+package validate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsIBAN(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		expected bool
+	}{
+		{"", true},
+		{"GB82WEST12345698765432", true},
+		{"GB82 WEST 1234 5698 7654 32", true},
+		{"gb82west12345698765432", true},
+		{"DE89370400440532013000", true},
+		{"FR1420041010050500013M02606", true},
+		{"NL91ABNA0417164300", true},
+		{"BE68539007547034", true},
+		{"NO9386011117947", true},
+		{"LY83002048000020100120361", true},
+		{"RU0204452560040702810412345678901", true},
+		{"SD2129010501234001", true},
+		{"BI4210000100010000332045181", true},
+		{"DJ2100010000000154000100186", true},
+		{"SO211000001001000100141", true},
+		{"NI45BAPR00000013000003558124", true},
+		{"MN121234123456789123", true},
+		{"OM810180000001299123456", true},
+		{"YE15CBYE0001018861234567891234", true},
+		{"FK88SC123456789012", true},
+		{"HN88CABF00000000000250005469", true},
+		{"LY8300204800002010012036", false},
+		{"GB82WEST12345698765431", false},
+		{"GB82WEST1234569876543", false},
+		{"XX82WEST12345698765432", false},
+		{"US64SVBKUS6S3300958879", false},
+		{"GB82-WEST-1234-5698-7654-32", false},
+		{"foo", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsIBAN(test.param), test.param)
+	}
+}
+
+func TestIsBIC(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		expected bool
+	}{
+		{"", true},
+		{"DEUTDEFF", true},
+		{"DEUTDEFF500", true},
+		{"NWBKGB2L", true},
+		{"deutdeff", true},
+		{"DEUTXXFF", false},
+		{"DEUTDEFF50", false},
+		{"DEU1DEFF", false},
+		{"DEUTDEFF5000", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsBIC(test.param), test.param)
+	}
+}
+
+func TestIsVATID(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		expected bool
+	}{
+		{"", true},
+		{"ATU13585627", true},
+		{"BE0428759497", true},
+		{"BE 0428.759.497", true},
+		{"DE136695976", true},
+		{"DK13585628", true},
+		{"EE100931558", true},
+		{"EL094259216", true},
+		{"FI20774740", true},
+		{"FR40303265045", true},
+		{"HR33392005961", true},
+		{"HU12892312", true},
+		{"IT00743110157", true},
+		{"LU26375245", true},
+		{"NL004495445B01", true},
+		{"PL5260250274", true},
+		{"PT501964843", true},
+		{"SE556188840401", true},
+		{"SI50223054", true},
+		{"SK2022749619", true},
+		{"ESX1234567L", true},
+		{"XI123456789", true},
+
+		{"ATU13585626", false},
+		{"BE0428759496", false},
+		{"DE136695977", false},
+		{"DK13585627", false},
+		{"EL094259217", false},
+		{"FR41303265045", false},
+		{"IT00743110158", false},
+		{"NL004495446B01", false},
+		{"PL5260250275", false},
+		{"SE556188840402", false},
+		{"GR094259216", false},
+		{"US123456789", false},
+		{"DE12345678", false},
+		{"DE", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsVATID(test.param), test.param)
+	}
+}
+
+func TestPaymentRules(t *testing.T) {
+	t.Parallel()
+
+	type Payout struct {
+		IBAN  string `json:"iban" valid:"required|iban"`
+		BIC   string `json:"bic" valid:"bic"`
+		VATID string `json:"vatId" valid:"vatid|name=VAT ID"`
+	}
+
+	bag, err := ValidateStruct(Payout{IBAN: "DE89370400440532013000", BIC: "COBADEFFXXX", VATID: "DE136695976"})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	bag, err = ValidateStruct(Payout{IBAN: "DE89370400440532013001", BIC: "COBADEF", VATID: "DE136695977"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"iban:iban", "bic:bic", "vatId:vatid"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "VAT ID must be a valid VAT number", bag.Errors()[2].Message())
+}
@@ -0,0 +1,141 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"github.com/ttacon/libphonenumber"
+	"regexp"
+	"strings"
+)
+
+// phoneE164Param restricts the phone rule to numbers written in E.164 format, e.g. phone(e164)
+const phoneE164Param = "e164"
+
+// phoneNumberTypes maps the type names accepted as phone params to libphonenumber types. Numbers that can't be told
+// apart (most US numbers) are FIXED_LINE_OR_MOBILE, which both mobile and fixedline accept.
+var phoneNumberTypes = map[string][]libphonenumber.PhoneNumberType{
+	"mobile":      {libphonenumber.MOBILE, libphonenumber.FIXED_LINE_OR_MOBILE},
+	"fixedline":   {libphonenumber.FIXED_LINE, libphonenumber.FIXED_LINE_OR_MOBILE},
+	"tollfree":    {libphonenumber.TOLL_FREE},
+	"premiumrate": {libphonenumber.PREMIUM_RATE},
+	"sharedcost":  {libphonenumber.SHARED_COST},
+	"voip":        {libphonenumber.VOIP},
+	"personal":    {libphonenumber.PERSONAL_NUMBER},
+	"pager":       {libphonenumber.PAGER},
+	"uan":         {libphonenumber.UAN},
+	"voicemail":   {libphonenumber.VOICEMAIL},
+}
+
+var rxE164 = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// phoneOptions are the phone rule params
+type phoneOptions struct {
+	region   string
+	types    []libphonenumber.PhoneNumberType
+	e164Only bool
+}
+
+// parsePhoneParams reads the phone params in any order: number type names, e164, and the default region as a two or
+// three-letter country code. The region is usually a sibling field, e.g. phone(Country,mobile); an empty region
+// means none. A param that is none of these, such as a misspelled type or a field the struct doesn't have, is an
+// error.
+func parsePhoneParams(params []interface{}) (phoneOptions, error) {
+	var options phoneOptions
+	for _, param := range params {
+		if param == nil {
+			continue
+		}
+
+		name := strings.ToLower(strings.TrimSpace(toString(param)))
+		if name == "" {
+			continue
+		}
+		if types, ok := phoneNumberTypes[name]; ok {
+			options.types = append(options.types, types...)
+		} else if name == phoneE164Param {
+			options.e164Only = true
+		} else if region, ok := countryAlpha2(name); ok {
+			options.region = region
+		} else {
+			return options, fmt.Errorf("Unknown phone param %v: not a number type, e164, a country code or a field", param)
+		}
+	}
+	return options, nil
+}
+
+// ParsePhone parses a phone number with libphonenumber and checks that it's valid. Numbers in national format are
+// parsed for the default region, a two or three-letter country code; numbers in international format (+ and the
+// country calling code) are accepted for any region.
+func ParsePhone(str, region string) (*libphonenumber.PhoneNumber, bool) {
+	region, _ = countryAlpha2(region)
+	number, err := libphonenumber.Parse(str, region)
+	if err != nil || !libphonenumber.IsValidNumber(number) {
+		return nil, false
+	}
+	return number, true
+}
+
+// IsPhone is the phone validator. Params are the default region (or a sibling field holding it), the allowed number
+// types (mobile, fixedline, tollfree, voip...), and e164 to only accept numbers in E.164 format, e.g.
+// phone(Country,mobile). Empty string is valid; a number can't be valid for params that can't be read.
+func IsPhone(str string, params ...interface{}) bool {
+	if IsNull(str) {
+		return true
+	}
+
+	options, err := parsePhoneParams(params)
+	if err != nil {
+		return false
+	}
+	return isPhoneWith(str, options)
+}
+
+// isPhone is the phone validator. Unlike IsPhone, it reports params it can't read.
+func isPhone(ctx context.Context, val interface{}, params ...interface{}) (bool, error) {
+	str, ok := val.(string)
+	if !ok {
+		return false, fmt.Errorf("phone only validates strings; got %T", val)
+	}
+	options, err := parsePhoneParams(params)
+	if err != nil {
+		return false, err
+	}
+	return IsNull(str) || isPhoneWith(str, options), nil
+}
+
+func isPhoneWith(str string, options phoneOptions) bool {
+	if options.e164Only && !rxE164.MatchString(str) {
+		return false
+	}
+
+	number, ok := ParsePhone(str, options.region)
+	if !ok {
+		return false
+	}
+	if len(options.types) == 0 {
+		return true
+	}
+
+	numberType := libphonenumber.GetNumberType(number)
+	for _, t := range options.types {
+		if numberType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// SanitizeE164 is the e164 sanitizer. It rewrites valid phone numbers to E.164 format, parsing numbers in national
+// format for the region given as a param (or a sibling field), e.g. sanitize:"e164(Country)". Invalid numbers are
+// left as they are for the phone rule to report, and so are all numbers when the params can't be read.
+func SanitizeE164(str string, params ...interface{}) string {
+	options, err := parsePhoneParams(params)
+	if err != nil {
+		return str
+	}
+	number, ok := ParsePhone(strings.TrimSpace(str), options.region)
+	if !ok {
+		return str
+	}
+	return libphonenumber.Format(number, libphonenumber.E164)
+}
@@ -0,0 +1,91 @@
+package validate
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsPhone(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		params   []interface{}
+		expected bool
+	}{
+		{"", nil, true},
+		{"+1 201-555-0123", nil, true},
+		{"(201) 555-0123", nil, false},
+		{"(201) 555-0123", []interface{}{"US"}, true},
+		{"(201) 555-0123", []interface{}{"usa"}, true},
+		{"+44 7400 12345", nil, false},
+		{"not a number", []interface{}{"US"}, false},
+
+		{"07400 123456", []interface{}{"GB", "mobile"}, true},
+		{"0121 234 5678", []interface{}{"GB", "mobile"}, false},
+		{"0121 234 5678", []interface{}{"GB", "fixedline"}, true},
+		{"0121 234 5678", []interface{}{"GB", "mobile", "fixedline"}, true},
+		{"+1 201-555-0123", []interface{}{"mobile"}, true},
+		{"+1 800-555-0199", []interface{}{"mobile"}, false},
+		{"+1 800-555-0199", []interface{}{"tollfree"}, true},
+
+		{"+12015550123", []interface{}{"e164"}, true},
+		{"+1 201-555-0123", []interface{}{"e164"}, false},
+		{"(201) 555-0123", []interface{}{"US", "e164"}, false},
+
+		{"+1 201-555-0123", []interface{}{"mobiel"}, false},
+		{"+1 201-555-0123", []interface{}{""}, true},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsPhone(test.param, test.params...), fmt.Sprint(test.param, test.params))
+	}
+}
+
+func TestPhoneRegionFromSiblingField(t *testing.T) {
+	t.Parallel()
+
+	type Contact struct {
+		Country string `json:"country"`
+		Phone   string `json:"phone" valid:"phone(Country,mobile)"`
+	}
+
+	bag, err := ValidateStruct(Contact{Country: "DE", Phone: "01512 3456789"})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	bag, err = ValidateStruct(Contact{Country: "GB", Phone: "01512 3456789"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"phone:phone"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "Phone must be a valid phone number", bag.FirstErrorMessage())
+
+	// without a country, only numbers in international format are valid
+	bag, err = ValidateStruct(Contact{Phone: "+49 1512 3456789"})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+}
+
+func TestPhoneUnknownParams(t *testing.T) {
+	t.Parallel()
+
+	type Contact struct {
+		Country string `json:"country"`
+		Phone   string `json:"phone" valid:"phone(Contry,mobile)"`
+	}
+	_, err := ValidateStruct(Contact{Country: "DE", Phone: "01512 3456789"})
+	assert.NotNil(t, err)
+
+	_, err = ValidateVar("+1 201-555-0123", "phone(cellular)")
+	assert.NotNil(t, err)
+
+	assert.Equal(t, "07400 123456", SanitizeE164("07400 123456", "Contry"))
+}
+
+func TestSanitizeE164(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "+447400123456", SanitizeE164("07400 123456", "GB"))
+	assert.Equal(t, "+12015550123", SanitizeE164("+1 (201) 555-0123"))
+	assert.Equal(t, "07400 123456", SanitizeE164("07400 123456"))
+	assert.Equal(t, "not a number", SanitizeE164("not a number", "GB"))
+}
@@ -0,0 +1,107 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// postcodePatterns are the postal code formats by ISO 3166 alpha-2 country code. Codes are upper cased and trimmed
+// before matching.
+var postcodePatterns = map[string]*regexp.Regexp{
+	"AR": regexp.MustCompile(`^([A-HJ-NP-Z]\d{4}[A-Z]{3}|\d{4})$`),
+	"AT": regexp.MustCompile(`^\d{4}$`),
+	"AU": regexp.MustCompile(`^\d{4}$`),
+	"BE": regexp.MustCompile(`^[1-9]\d{3}$`),
+	"BG": regexp.MustCompile(`^\d{4}$`),
+	"BR": regexp.MustCompile(`^\d{5}-?\d{3}$`),
+	"CA": regexp.MustCompile(`^[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z] ?\d[ABCEGHJ-NPRSTV-Z]\d$`),
+	"CH": regexp.MustCompile(`^[1-9]\d{3}$`),
+	"CN": regexp.MustCompile(`^\d{6}$`),
+	"CY": regexp.MustCompile(`^\d{4}$`),
+	"CZ": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"DK": regexp.MustCompile(`^\d{4}$`),
+	"EE": regexp.MustCompile(`^\d{5}$`),
+	"ES": regexp.MustCompile(`^(0[1-9]|[1-4]\d|5[0-2])\d{3}$`),
+	"FI": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"GB": regexp.MustCompile(`^([A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}|GIR ?0AA)$`),
+	"GR": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"HR": regexp.MustCompile(`^\d{5}$`),
+	"HU": regexp.MustCompile(`^[1-9]\d{3}$`),
+	"IE": regexp.MustCompile(`^([AC-FHKNPRTV-Y]\d{2}|D6W) ?[0-9AC-FHKNPRTV-Y]{4}$`),
+	"IL": regexp.MustCompile(`^\d{7}$`),
+	"IN": regexp.MustCompile(`^[1-9]\d{5}$`),
+	"IS": regexp.MustCompile(`^\d{3}$`),
+	"IT": regexp.MustCompile(`^\d{5}$`),
+	"JP": regexp.MustCompile(`^\d{3}-?\d{4}$`),
+	"KR": regexp.MustCompile(`^\d{5}$`),
+	"LT": regexp.MustCompile(`^(LT-)?\d{5}$`),
+	"LU": regexp.MustCompile(`^(L-)?\d{4}$`),
+	"LV": regexp.MustCompile(`^(LV-)?\d{4}$`),
+	"MT": regexp.MustCompile(`^[A-Z]{3} ?\d{4}$`),
+	"MX": regexp.MustCompile(`^\d{5}$`),
+	"NL": regexp.MustCompile(`^[1-9]\d{3} ?[A-Z]{2}$`),
+	"NO": regexp.MustCompile(`^\d{4}$`),
+	"NZ": regexp.MustCompile(`^\d{4}$`),
+	"PL": regexp.MustCompile(`^\d{2}-\d{3}$`),
+	"PT": regexp.MustCompile(`^\d{4}-\d{3}$`),
+	"RO": regexp.MustCompile(`^\d{6}$`),
+	"RU": regexp.MustCompile(`^\d{6}$`),
+	"SE": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"SG": regexp.MustCompile(`^\d{6}$`),
+	"SI": regexp.MustCompile(`^\d{4}$`),
+	"SK": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"TR": regexp.MustCompile(`^\d{5}$`),
+	"UA": regexp.MustCompile(`^\d{5}$`),
+	"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+	"ZA": regexp.MustCompile(`^\d{4}$`),
+}
+
+// rxPostcode is the format of postal codes of countries without a pattern, or when the country isn't known
+var rxPostcode = regexp.MustCompile(`^[A-Z0-9][A-Z0-9 -]{1,8}[A-Z0-9]$`)
+
+// IsPostcode check if the string is a postal code of country, given as an ISO 3166 alpha-2 or alpha-3 code.
+// Countries without a known format accept 3 to 10 letters, digits, spaces and dashes; so does an empty country.
+// Empty string is valid.
+func IsPostcode(str string, country string) bool {
+	if IsNull(str) {
+		return true
+	}
+
+	rx := rxPostcode
+	if strings.TrimSpace(country) != "" {
+		code, ok := countryAlpha2(country)
+		if !ok {
+			return false
+		}
+		if pattern, ok := postcodePatterns[code]; ok {
+			rx = pattern
+		}
+	}
+	return rx.MatchString(strings.ToUpper(strings.TrimSpace(str)))
+}
+
+// isPostcode is the postcode(country) validator. The country is an ISO 3166 code, e.g. postcode(DE), or the name
+// of the sibling field holding it, e.g. postcode(Country). A param that is neither, such as a misspelled field name,
+// is an error; an empty country accepts any postal code format.
+func isPostcode(ctx context.Context, val interface{}, params ...interface{}) (bool, error) {
+	str, ok := val.(string)
+	if !ok {
+		return false, fmt.Errorf("postcode only validates strings; got %T", val)
+	}
+	if len(params) != 1 {
+		return false, fmt.Errorf("postcode needs a country, e.g. postcode(DE) or postcode(Country)")
+	}
+
+	var country string
+	if params[0] != nil {
+		country = strings.TrimSpace(toString(params[0]))
+	}
+	if _, ok := countryAlpha2(country); country != "" && !ok {
+		return false, fmt.Errorf("Unknown postcode param %v: not a country code or a field", params[0])
+	}
+	return IsPostcode(str, country), nil
+}
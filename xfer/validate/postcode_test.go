@@ -0,0 +1,92 @@
+package validate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsPostcode(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		country  string
+		expected bool
+	}{
+		{"", "DE", true},
+		{"10115", "DE", true},
+		{"10115", "deu", true},
+		{"1011", "DE", false},
+		{"90210", "US", true},
+		{"90210-1234", "US", true},
+		{"90210-12", "US", false},
+		{"SW1A 1AA", "GB", true},
+		{"sw1a1aa", "GB", true},
+		{"SW1A 1A", "GB", false},
+		{"K1A 0B1", "CA", true},
+		{"D1A 0B1", "CA", false},
+		{"1012 AB", "NL", true},
+		{"0123 AB", "NL", false},
+		{"00-950", "PL", true},
+		{"00950", "PL", false},
+		{"53000", "ES", false},
+		{"28013", "ES", true},
+		{"D02 X285", "IE", true},
+		{"100-0001", "JP", true},
+		// no known format
+		{"00100", "KE", true},
+		{"x", "KE", false},
+		{"12345", "", true},
+		{"12345", "XX", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsPostcode(test.param, test.country), test.country+" "+test.param)
+	}
+}
+
+type PostalAddress struct {
+	Country  string  `json:"country" valid:"required|isoalpha2"`
+	Postcode string  `json:"postcode" valid:"postcode(Country)"`
+	Billing  *string `json:"billing" valid:"postcode(US)"`
+}
+
+func TestPostcodeRule(t *testing.T) {
+	t.Parallel()
+
+	billing := "90210"
+	bag, err := ValidateStruct(PostalAddress{Country: "FR", Postcode: "75008", Billing: &billing})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	bag, err = ValidateStruct(PostalAddress{Country: "GB", Postcode: "75008"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"postcode:postcode"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "Postcode must be a valid postal code", bag.FirstErrorMessage())
+
+	billing = "SW1A 1AA"
+	bag, err = ValidateStruct(PostalAddress{Country: "GB", Postcode: "SW1A 1AA", Billing: &billing})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"billing:postcode"}, embeddedErrorPaths(bag))
+
+	_, err = ValidateVar("75008", "postcode")
+	assert.NotNil(t, err)
+}
+
+func TestPostcodeUnknownParams(t *testing.T) {
+	t.Parallel()
+
+	type Address struct {
+		Country  string `json:"country"`
+		Postcode string `json:"postcode" valid:"postcode(Countr)"`
+	}
+	_, err := ValidateStruct(Address{Country: "FR", Postcode: "75008"})
+	assert.NotNil(t, err)
+
+	_, err = ValidateVar("75008", "postcode(XX)")
+	assert.NotNil(t, err)
+
+	// an empty country field accepts any postal code format
+	bag, err := ValidateStruct(PostalAddress{Postcode: "75008"})
+	assert.Nil(t, err)
+	assert.NotContains(t, embeddedErrorPaths(bag), "postcode:postcode")
+}
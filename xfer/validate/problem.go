@@ -0,0 +1,54 @@
+package validate
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ProblemContentType is the content type of RFC 7807 problem details
+const ProblemContentType = `application/problem+json`
+
+// Defaults for the problem details of validation errors. Type should be set to a URI documenting the problem if
+// there is one; about:blank means the problem has no more semantics than its HTTP status.
+var (
+	ProblemType   = `about:blank`
+	ProblemTitle  = `Your request parameters didn't validate.`
+	ProblemStatus = http.StatusBadRequest
+)
+
+// Problem is an RFC 7807 problem details body, extended with the validation errors
+type Problem struct {
+	Type     string  `json:"type"`
+	Title    string  `json:"title"`
+	Status   int     `json:"status,omitempty"`
+	Detail   string  `json:"detail,omitempty"`
+	Instance string  `json:"instance,omitempty"`
+	Errors   []Error `json:"errors"`
+}
+
+// Problem returns the problem details for the errors in the bag. The rejected values are left out (see
+// ProblemWithValues).
+func (eb *ErrorBag) Problem() Problem {
+	return Problem{
+		Type:   ProblemType,
+		Title:  ProblemTitle,
+		Status: ProblemStatus,
+		Errors: eb.Errors(),
+	}
+}
+
+// ProblemWithValues is Problem with the rejected value of each error, for trusted clients and internal tools.
+// Redacted values are still replaced with RedactedValue.
+func (eb *ErrorBag) ProblemWithValues() Problem {
+	problem := eb.Problem()
+	for i := range problem.Errors {
+		problem.Errors[i].marshalValue = true
+	}
+	return problem
+}
+
+// MarshalJSON marshals the bag as an RFC 7807 problem details body (see Problem). Use ProblemContentType as the
+// response content type.
+func (eb *ErrorBag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(eb.Problem())
+}
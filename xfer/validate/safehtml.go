@@ -0,0 +1,310 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+)
+
+// HTML policy names
+const (
+	StrictHTMLPolicy = "strict"
+	BasicHTMLPolicy  = "basic"
+	UGCHTMLPolicy    = "ugc"
+)
+
+// HTMLPolicy is an allowlist of the HTML a sanitized string can hold. Other elements are removed but their text is
+// kept, except for elements whose content isn't text, such as script and style, which are removed whole. Comments
+// and doctypes are always removed.
+type HTMLPolicy struct {
+	// Elements maps the allowed elements to their allowed attributes, lower cased
+	Elements map[string][]string
+
+	// URLSchemes are the schemes allowed in URL attributes (href, src, cite). Relative URLs are always allowed.
+	URLSchemes []string
+
+	// RelNofollow sets rel="nofollow" on links, replacing any rel attribute, so that user content doesn't pass
+	// search ranking to the sites it links to
+	RelNofollow bool
+}
+
+// basicHTMLElements are the formatting elements of the basic and UGC policies
+var basicHTMLElements = map[string][]string{
+	"b": nil, "strong": nil, "i": nil, "em": nil, "u": nil, "s": nil, "del": nil, "ins": nil, "mark": nil,
+	"small": nil, "sub": nil, "sup": nil, "code": nil, "pre": nil, "kbd": nil, "br": nil, "hr": nil, "p": nil,
+	"blockquote": nil, "ul": nil, "ol": nil, "li": nil,
+}
+
+var htmlPolicies = struct {
+	sync.RWMutex
+	policies map[string]HTMLPolicy
+}{
+	policies: map[string]HTMLPolicy{
+		StrictHTMLPolicy: {},
+		BasicHTMLPolicy:  {Elements: basicHTMLElements},
+		UGCHTMLPolicy: {
+			Elements: mergeHTMLElements(basicHTMLElements, map[string][]string{
+				"a": {"href", "title"}, "h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+				"blockquote": {"cite"}, "abbr": {"title"}, "dl": nil, "dt": nil, "dd": nil,
+			}),
+			URLSchemes:  []string{"http", "https", "mailto"},
+			RelNofollow: true,
+		},
+	},
+}
+
+func mergeHTMLElements(sets ...map[string][]string) map[string][]string {
+	merged := map[string][]string{}
+	for _, set := range sets {
+		for element, attrs := range set {
+			merged[element] = attrs
+		}
+	}
+	return merged
+}
+
+// RegisterHTMLPolicy adds a policy for the safehtml(name) rule and sanitizer, or replaces the one with the same name
+func RegisterHTMLPolicy(name string, policy HTMLPolicy) error {
+	if len(name) == 0 || strings.ContainsAny(name, validatorSeparator+paramSeparator+paramCloseToken) {
+		return fmt.Errorf("%q is not a valid HTML policy name", name)
+	}
+	for element := range policy.Elements {
+		if htmlDropContent[element] {
+			return fmt.Errorf("HTML policy %s can't allow %s elements", name, element)
+		}
+	}
+
+	htmlPolicies.Lock()
+	defer htmlPolicies.Unlock()
+	htmlPolicies.policies[name] = policy
+	return nil
+}
+
+// GetHTMLPolicy returns a registered HTML policy
+func GetHTMLPolicy(name string) (HTMLPolicy, bool) {
+	htmlPolicies.RLock()
+	defer htmlPolicies.RUnlock()
+	policy, ok := htmlPolicies.policies[name]
+	return policy, ok
+}
+
+// htmlDropContent are the elements removed with their content, which is code, markup or form state rather than
+// text. Policies can't allow them.
+var htmlDropContent = map[string]bool{
+	"script": true, "style": true, "iframe": true, "frame": true, "frameset": true, "object": true, "embed": true,
+	"applet": true, "noscript": true, "noembed": true, "noframes": true, "template": true, "title": true,
+	"textarea": true, "select": true, "xmp": true, "plaintext": true, "svg": true, "math": true,
+}
+
+// htmlVoidElements have no content and no end tag
+var htmlVoidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true, "input": true,
+	"link": true, "meta": true, "param": true, "source": true, "track": true, "wbr": true,
+}
+
+// htmlURLAttributes are the attributes holding URLs, whose scheme is checked
+var htmlURLAttributes = map[string]bool{"href": true, "src": true, "cite": true}
+
+// safeURL is true if the URL is relative or uses one of the schemes
+func safeURL(str string, schemes []string) bool {
+	// browsers ignore control characters and spaces in URLs, so java\tscript: is javascript:
+	str = strings.Map(func(c rune) rune {
+		if c <= ' ' || c == 0x7f {
+			return -1
+		}
+		return c
+	}, str)
+
+	u, err := url.Parse(str)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" {
+		return true
+	}
+	for _, scheme := range schemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeHTML returns str with only the HTML the policy allows. removed is true if anything unsafe was removed;
+// rewriting the HTML the policy allows (quoting attributes, closing open elements...) doesn't count.
+func sanitizeHTML(str string, policy HTMLPolicy) (sanitized string, removed bool) {
+	var buf bytes.Buffer
+	z := html.NewTokenizer(strings.NewReader(str))
+
+	// open are the allowed elements waiting for their end tag. skip is the element whose content is being dropped,
+	// and depth how many of them are open.
+	open := make([]string, 0)
+	skip, depth := "", 0
+
+	for {
+		tokenType := z.Next()
+		if tokenType == html.ErrorToken {
+			if z.Err() != io.EOF {
+				removed = true
+			}
+			break
+		}
+
+		token := z.Token()
+		if depth > 0 {
+			if token.Data == skip && tokenType == html.StartTagToken {
+				depth++
+			} else if token.Data == skip && tokenType == html.EndTagToken {
+				depth--
+			}
+			continue
+		}
+
+		switch tokenType {
+		case html.TextToken:
+			buf.WriteString(html.EscapeString(token.Data))
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			attrs, ok := policy.Elements[token.Data]
+			if !ok {
+				removed = true
+				// <script/> is still an open script to browsers, as are the other self-closing elements of HTML
+				if htmlDropContent[token.Data] && !htmlVoidElements[token.Data] {
+					skip, depth = token.Data, 1
+				}
+				continue
+			}
+
+			if writeHTMLStartTag(&buf, token, attrs, policy) {
+				removed = true
+			}
+			if tokenType == html.StartTagToken && !htmlVoidElements[token.Data] {
+				open = append(open, token.Data)
+			} else if !htmlVoidElements[token.Data] {
+				// <p/> is an open p to browsers; close it straight away rather than leave it open
+				buf.WriteString("</" + token.Data + ">")
+			}
+
+		case html.EndTagToken:
+			// end tags close the last open element of their kind, and any element still open in it
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == token.Data {
+					for j := len(open) - 1; j >= i; j-- {
+						buf.WriteString("</" + open[j] + ">")
+					}
+					open = open[:i]
+					break
+				}
+			}
+			if _, ok := policy.Elements[token.Data]; !ok {
+				removed = true
+			}
+
+		default:
+			// comments and doctypes
+			removed = true
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		buf.WriteString("</" + open[i] + ">")
+	}
+	return buf.String(), removed
+}
+
+// writeHTMLStartTag writes the start tag of an allowed element with its allowed attributes, in a stable order. It
+// returns true if an attribute was removed.
+func writeHTMLStartTag(buf *bytes.Buffer, token html.Token, allowed []string, policy HTMLPolicy) (removed bool) {
+	attrs := make([]html.Attribute, 0, len(token.Attr))
+	seen := map[string]bool{}
+	for _, attr := range token.Attr {
+		if token.Data == "a" && attr.Key == "rel" && policy.RelNofollow {
+			continue
+		}
+		if seen[attr.Key] || !containsString(allowed, attr.Key) ||
+			(htmlURLAttributes[attr.Key] && !safeURL(attr.Val, policy.URLSchemes)) {
+			removed = true
+			continue
+		}
+		seen[attr.Key] = true
+		attrs = append(attrs, attr)
+	}
+	if token.Data == "a" && policy.RelNofollow {
+		attrs = append(attrs, html.Attribute{Key: "rel", Val: "nofollow"})
+	}
+	sort.SliceStable(attrs, func(i, j int) bool { return attrs[i].Key < attrs[j].Key })
+
+	buf.WriteString("<" + token.Data)
+	for _, attr := range attrs {
+		buf.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+	}
+	buf.WriteString(">")
+	return removed
+}
+
+func containsString(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// SanitizeHTML returns str with only the HTML the policy allows, re-serialized so that what's left can't be read
+// differently by browsers: text and attributes are escaped, attributes quoted and open elements closed.
+func SanitizeHTML(str string, policy HTMLPolicy) string {
+	sanitized, _ := sanitizeHTML(str, policy)
+	return sanitized
+}
+
+// IsSafeHTML check if sanitizing str with the policy would only rewrite it, not remove anything from it. Empty
+// string is valid.
+func IsSafeHTML(str string, policy HTMLPolicy) bool {
+	_, removed := sanitizeHTML(str, policy)
+	return !removed
+}
+
+// htmlPolicyParam returns the policy named by the first safehtml param, StrictHTMLPolicy without params
+func htmlPolicyParam(params []interface{}) (HTMLPolicy, error) {
+	name := StrictHTMLPolicy
+	if len(params) > 0 {
+		name = strings.TrimSpace(toString(params[0]))
+	}
+	policy, ok := GetHTMLPolicy(name)
+	if !ok {
+		return policy, fmt.Errorf("Unknown HTML policy %s", name)
+	}
+	return policy, nil
+}
+
+// isSafeHTML is the safehtml(policy) validator: the value must only hold the HTML the policy allows, e.g.
+// safehtml(ugc). Use the safehtml sanitizer to remove the rest instead.
+func isSafeHTML(ctx context.Context, val interface{}, params ...interface{}) (bool, error) {
+	str, ok := val.(string)
+	if !ok {
+		return false, fmt.Errorf("safehtml only validates strings; got %T", val)
+	}
+	policy, err := htmlPolicyParam(params)
+	if err != nil {
+		return false, err
+	}
+	return IsSafeHTML(str, policy), nil
+}
+
+// sanitizeSafeHTML is the safehtml(policy) sanitizer. As a sanitizer can't fail, an unknown policy leaves text only,
+// like the strict one.
+func sanitizeSafeHTML(str string, params ...interface{}) string {
+	policy, err := htmlPolicyParam(params)
+	if err != nil {
+		policy = HTMLPolicy{}
+	}
+	return SanitizeHTML(str, policy)
+}
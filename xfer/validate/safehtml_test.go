@@ -0,0 +1,130 @@
+package validate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	t.Parallel()
+
+	strict, _ := GetHTMLPolicy(StrictHTMLPolicy)
+	basic, _ := GetHTMLPolicy(BasicHTMLPolicy)
+	ugc, _ := GetHTMLPolicy(UGCHTMLPolicy)
+
+	var tests = []struct {
+		param    string
+		policy   HTMLPolicy
+		expected string
+		safe     bool
+	}{
+		{"", strict, "", true},
+		{"Tom & Jerry", strict, "Tom &amp; Jerry", true},
+		{"Tom &amp; Jerry", strict, "Tom &amp; Jerry", true},
+		{"a < b", strict, "a &lt; b", true},
+		{"<b>bold</b> move", strict, "bold move", false},
+		{"<script>alert(1)</script>hi", strict, "hi", false},
+		{"<SCRIPT>alert(1)</SCRIPT>hi", basic, "hi", false},
+		{"<script/>alert(1)</script>hi", basic, "hi", false},
+		{"<style/>body{display:none}</style>hi", basic, "hi", false},
+		{"<textarea/><b>text</b></textarea>hi", basic, "hi", false},
+		{"<style>body{display:none}</style><p>hi</p>", basic, "<p>hi</p>", false},
+		{"<b>bold</b> <em>and</em><br>more", basic, "<b>bold</b> <em>and</em><br>more", true},
+		{"<b class=x onclick=\"alert(1)\">bold</b>", basic, "<b>bold</b>", false},
+		{"<p>one<p>two", basic, "<p>one<p>two</p></p>", true},
+		{"<ul><li>one</ul>", basic, "<ul><li>one</li></ul>", true},
+		{"<b>stray</i></b>", basic, "<b>stray</b>", true},
+		{"<div><p>text</p></div>", basic, "<p>text</p>", false},
+		{"<p/>text", basic, "<p></p>text", true},
+		{"<img src=x onerror=alert(1)>", basic, "", false},
+		{"<!-- comment -->text", basic, "text", false},
+		{"<svg><svg><script>alert(1)</script></svg>still svg</svg>after", basic, "after", false},
+		{"<iframe src=\"https://evil.com\"></iframe>", ugc, "", false},
+		{"<a href=\"https://example.com/?a=1&b=2\">link</a>", ugc, "<a href=\"https://example.com/?a=1&amp;b=2\" rel=\"nofollow\">link</a>", true},
+		{"<a href=\"/relative\" rel=\"opener\">link</a>", ugc, "<a href=\"/relative\" rel=\"nofollow\">link</a>", true},
+		{"<a href=\"javascript:alert(1)\">link</a>", ugc, "<a rel=\"nofollow\">link</a>", false},
+		{"<a href=\"JaVaScRiPt:alert(1)\">link</a>", ugc, "<a rel=\"nofollow\">link</a>", false},
+		{"<a href=\"java\tscript:alert(1)\">link</a>", ugc, "<a rel=\"nofollow\">link</a>", false},
+		{"<a href=\"&#106;avascript:alert(1)\">link</a>", ugc, "<a rel=\"nofollow\">link</a>", false},
+		{"<a href=\"data:text/html,<script>alert(1)</script>\">link</a>", ugc, "<a rel=\"nofollow\">link</a>", false},
+		{"<a href=\"mailto:ann@example.com\" title='Say \"hi\"'>mail</a>", ugc,
+			"<a href=\"mailto:ann@example.com\" rel=\"nofollow\" title=\"Say &#34;hi&#34;\">mail</a>", true},
+		{"<a href=\"https://example.com\">link</a>", basic, "link", false},
+		{"<h1>Title</h1><blockquote cite=\"https://example.com\">quote</blockquote>", ugc,
+			"<h1>Title</h1><blockquote cite=\"https://example.com\">quote</blockquote>", true},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, SanitizeHTML(test.param, test.policy), test.param)
+		assert.Equal(t, test.safe, IsSafeHTML(test.param, test.policy), test.param)
+
+		// sanitized HTML is safe, and sanitizing it again doesn't change it
+		sanitized := SanitizeHTML(test.param, test.policy)
+		assert.True(t, IsSafeHTML(sanitized, test.policy), sanitized)
+		assert.Equal(t, sanitized, SanitizeHTML(sanitized, test.policy))
+	}
+}
+
+func TestRemoveTags(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Hello world", RemoveTags("<p>Hello <b>world</b></p>"))
+	assert.Equal(t, "Hello ", RemoveTags("Hello <script>document.write('<b>x</b>')</script>"))
+	assert.Equal(t, "1 < 2", RemoveTags("1 < 2"))
+	assert.Equal(t, "Tom & Jerry", RemoveTags("Tom & Jerry"))
+	assert.Equal(t, "Tom & Jerry", RemoveTags("<i>Tom &amp; Jerry</i>"))
+	assert.Equal(t, "ok", RemoveTags("<script/>alert(1)</script>ok"))
+	assert.Equal(t, "", RemoveTags("<img src=x onerror=alert(1)//"))
+}
+
+func TestRegisterHTMLPolicy(t *testing.T) {
+	t.Parallel()
+
+	assert.NotNil(t, RegisterHTMLPolicy("", HTMLPolicy{}))
+	assert.NotNil(t, RegisterHTMLPolicy("a,b", HTMLPolicy{}))
+	assert.NotNil(t, RegisterHTMLPolicy("scripts", HTMLPolicy{Elements: map[string][]string{"script": nil}}))
+	assert.Nil(t, RegisterHTMLPolicy("testimages", HTMLPolicy{
+		Elements:   map[string][]string{"img": {"src", "alt"}},
+		URLSchemes: []string{"https"},
+	}))
+
+	policy, ok := GetHTMLPolicy("testimages")
+	assert.True(t, ok)
+	assert.Equal(t, "<img alt=\"cat\" src=\"https://example.com/cat.png\">",
+		SanitizeHTML("<img src=\"https://example.com/cat.png\" alt=cat>", policy))
+	assert.Equal(t, "<img>", SanitizeHTML("<img src=\"http://example.com/cat.png\">", policy))
+}
+
+type Comment struct {
+	Author string `json:"author" sanitize:"safehtml" valid:"safehtml"`
+	Body   string `json:"body" sanitize:"safehtml(ugc)" valid:"required|safehtml(ugc)"`
+	Bio    string `json:"bio" valid:"safehtml(basic)"`
+}
+
+func TestSafeHTMLRule(t *testing.T) {
+	t.Parallel()
+
+	bag, err := ValidateStruct(Comment{Author: "Ann", Body: "<p>Nice <a href=\"https://example.com\">post</a></p>", Bio: "<em>Hi</em>"})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	comment := Comment{
+		Author: "<b>Ann</b>",
+		Body:   "<p onmouseover=\"steal()\">Nice <a href=\"javascript:steal()\">post</a></p><script>steal()</script>",
+		Bio:    "<div>Hi</div>",
+	}
+	bag, err = ValidateStruct(comment)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"author:safehtml", "body:safehtml", "bio:safehtml"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "Body contains HTML that isn't allowed", bag.Errors()[1].Message())
+
+	assert.Nil(t, SanitizeStruct(&comment))
+	assert.Equal(t, "Ann", comment.Author)
+	assert.Equal(t, "<p>Nice <a rel=\"nofollow\">post</a></p>", comment.Body)
+	bag, err = ValidateStruct(comment)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"bio:safehtml"}, embeddedErrorPaths(bag))
+
+	_, err = ValidateVar("<b>x</b>", "safehtml(nosuchpolicy)")
+	assert.NotNil(t, err)
+	assert.Equal(t, "x", sanitizeSafeHTML("<b>x</b>", "nosuchpolicy"))
+}
@@ -0,0 +1,144 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// sanitizeTagName is the tag listing the sanitizers of a field, in the order they run, e.g. sanitize:"trim|e164(Country)".
+// Sanitizers take params like validators.
+const sanitizeTagName = "sanitize"
+
+// EmSanitizer rewrites string fields before they're validated
+type EmSanitizer struct {
+	Op func(str string, params ...interface{}) string
+
+	// FieldParams makes params that name another field of the struct stand for that field's value, as for
+	// validators
+	FieldParams bool
+}
+
+var sanitizers = map[string]*EmSanitizer{}
+
+func init() {
+	sanitizers["trim"] = &EmSanitizer{Op: func(str string, params ...interface{}) string {
+		return Trim(str, "")
+	}}
+	sanitizers["e164"] = &EmSanitizer{Op: SanitizeE164, FieldParams: true}
+	sanitizers["slug"] = &EmSanitizer{Op: Slug}
+	sanitizers["nfc"] = &EmSanitizer{Op: NFC}
+	sanitizers["safehtml"] = &EmSanitizer{Op: sanitizeSafeHTML}
+}
+
+// RegisterSanitizer adds a sanitizer, or replaces the one registered with the same key. It must be called before
+// sanitizing, usually in init() methods.
+func RegisterSanitizer(key string, es EmSanitizer) error {
+	if len(key) == 0 || strings.ContainsAny(key, validatorSeparator+settingsToken+paramOpenToken+paramCloseToken+"!") {
+		return fmt.Errorf("%q is not a valid sanitizer key", key)
+	}
+	if es.Op == nil {
+		return fmt.Errorf("Sanitizer %s has no Op", key)
+	}
+
+	sanitizers[key] = &es
+	return nil
+}
+
+// fieldSanitizer is a sanitizer with its params, as set in a sanitize tag
+type fieldSanitizer struct {
+	sanitizer *EmSanitizer
+	params    []interface{}
+}
+
+// SanitizeStruct rewrites the fields of the struct s points to with the sanitizers in their sanitize tags. String
+// fields and pointers to strings are sanitized, as are the elements of string slices and arrays; nested structs and
+// slices of structs are sanitized recursively. Run it before ValidateStruct so the rules see the sanitized values.
+func SanitizeStruct(s interface{}) error {
+	v := reflect.ValueOf(s)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("SanitizeStruct needs a pointer to a struct; got %T", s)
+	}
+	return sanitizeStructFields(v.Elem())
+}
+
+func sanitizeStructFields(obj reflect.Value) error {
+	for i := 0; i < obj.NumField(); i++ {
+		t := obj.Type().Field(i)
+		if t.PkgPath != "" && !t.Anonymous {
+			continue
+		}
+
+		fieldSanitizers, err := getFieldSanitizers(t)
+		if err != nil {
+			return err
+		}
+		if err := sanitizeValue(obj.Field(i), obj, fieldSanitizers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sanitizeValue(v reflect.Value, parent reflect.Value, fieldSanitizers []fieldSanitizer) error {
+	switch v.Kind() {
+	case reflect.String:
+		if len(fieldSanitizers) > 0 && v.CanSet() {
+			v.SetString(sanitizeString(v.String(), parent, fieldSanitizers))
+		}
+	case reflect.Ptr:
+		if !v.IsNil() {
+			return sanitizeValue(v.Elem(), parent, fieldSanitizers)
+		}
+	case reflect.Struct:
+		if !isWrapperType(v.Type()) {
+			return sanitizeStructFields(v)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := sanitizeValue(v.Index(i), parent, fieldSanitizers); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func sanitizeString(str string, parent reflect.Value, fieldSanitizers []fieldSanitizer) string {
+	for _, fs := range fieldSanitizers {
+		params := fs.params
+		if fs.sanitizer.FieldParams {
+			params = resolveFieldParams(parent, params)
+		}
+		str = fs.sanitizer.Op(str, params...)
+	}
+	return str
+}
+
+// getFieldSanitizers parses the sanitize tag of a field
+func getFieldSanitizers(t reflect.StructField) ([]fieldSanitizer, error) {
+	tag := t.Tag.Get(sanitizeTagName)
+	if len(tag) == 0 {
+		return nil, nil
+	}
+
+	fieldSanitizers := make([]fieldSanitizer, 0)
+	for _, key := range strings.Split(tag, validatorSeparator) {
+		if len(key) == 0 {
+			continue
+		}
+
+		key, params, err := params(key, t.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		sanitizer, ok := sanitizers[key]
+		if !ok {
+			return nil, fmt.Errorf("Invalid sanitizer key for field %s: %s", t.Name, key)
+		}
+		fieldSanitizers = append(fieldSanitizers, fieldSanitizer{sanitizer: sanitizer, params: params})
+	}
+
+	return fieldSanitizers, nil
+}
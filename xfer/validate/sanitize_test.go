@@ -0,0 +1,75 @@
+package validate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func init() {
+	err := RegisterSanitizer("testupper", EmSanitizer{Op: func(str string, params ...interface{}) string {
+		return strings.ToUpper(str)
+	}})
+	if err != nil {
+		panic(err.Error())
+	}
+}
+
+type sanitizedContact struct {
+	Country string   `json:"country" sanitize:"trim|testupper" valid:"isoalpha2"`
+	Phone   string   `json:"phone" sanitize:"trim|e164(Country)" valid:"phone(Country,e164)"`
+	Mobile  *string  `json:"mobile" sanitize:"e164(Country)"`
+	Aliases []string `json:"aliases" sanitize:"trim"`
+	note    string   `sanitize:"trim"`
+}
+
+type sanitizedAccount struct {
+	Name     string `json:"name" sanitize:"trim"`
+	Contact  sanitizedContact
+	Contacts []sanitizedContact
+}
+
+func TestSanitizeStruct(t *testing.T) {
+	t.Parallel()
+
+	mobile := "01512 3456789"
+	account := sanitizedAccount{
+		Name: "  Ann ",
+		Contact: sanitizedContact{
+			Country: " gb",
+			Phone:   " 07400 123456 ",
+			Aliases: []string{" a ", "b "},
+			note:    " kept ",
+		},
+		Contacts: []sanitizedContact{{Country: "de", Phone: "030 123456", Mobile: &mobile}},
+	}
+
+	assert.Nil(t, SanitizeStruct(&account))
+	assert.Equal(t, "Ann", account.Name)
+	assert.Equal(t, "GB", account.Contact.Country)
+	assert.Equal(t, "+447400123456", account.Contact.Phone)
+	assert.Nil(t, account.Contact.Mobile)
+	assert.Equal(t, []string{"a", "b"}, account.Contact.Aliases)
+	assert.Equal(t, " kept ", account.Contact.note)
+	assert.Equal(t, "+4930123456", account.Contacts[0].Phone)
+	assert.Equal(t, "+4915123456789", mobile)
+
+	bag, err := ValidateStruct(account.Contact)
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+}
+
+func TestSanitizeStructErrors(t *testing.T) {
+	t.Parallel()
+
+	assert.NotNil(t, SanitizeStruct(sanitizedContact{}))
+	assert.NotNil(t, SanitizeStruct((*sanitizedContact)(nil)))
+
+	type Bad struct {
+		Name string `sanitize:"nosuchsanitizer"`
+	}
+	assert.NotNil(t, SanitizeStruct(&Bad{}))
+
+	assert.NotNil(t, RegisterSanitizer("bad|key", EmSanitizer{Op: SanitizeE164}))
+	assert.NotNil(t, RegisterSanitizer("noop", EmSanitizer{}))
+}
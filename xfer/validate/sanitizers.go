@@ -117,9 +117,11 @@ func Reverse(s string) string {
 	return string(r)
 }
 
-// RemoveTags remove all tags from HTML string
+// RemoveTags remove all tags from HTML string, along with the content of elements such as script and style. The
+// result is plain text, with character references such as &amp; unescaped: escape it before writing it in HTML, or
+// use SanitizeHTML, which returns escaped HTML.
 func RemoveTags(s string) string {
-	return ReplacePattern(s, "<[^>]*>", "")
+	return html.UnescapeString(SanitizeHTML(s, HTMLPolicy{}))
 }
 
 // SafeFileName return safe string that can be used in file names
@@ -141,24 +143,24 @@ func SafeFileName(str string) string {
 	return name
 }
 
-// NormalizeEmail canonicalize an email address.
-// The local part of the email address is lowercased for all domains; the hostname is always lowercased and
-// the local part of the email address is always lowercased for hosts that are known to be case-insensitive (currently only GMail).
+// NormalizeEmail canonicalize an email address, parsed with ParseEmail.
+// The domain is lowercased. The local part is kept as is, as it's case-sensitive for most hosts, except for hosts
+// that are known to be case-insensitive (currently only GMail), where it's lowercased.
 // Normalization follows special rules for known providers: currently, GMail addresses have dots removed in the local part and
 // are stripped of tags (e.g. some.one+tag@gmail.com becomes someone@gmail.com) and all @googlemail.com addresses are
-// normalized to @gmail.com.
+// normalized to @gmail.com. Local parts that need quotes keep them.
 func NormalizeEmail(str string) (string, error) {
-	//if !IsEmail(str) {
-	//	return "", fmt.Errorf("%s is not an email", str)
-	//}
-	parts := strings.Split(str, "@")
-	parts[0] = strings.ToLower(parts[0])
-	parts[1] = strings.ToLower(parts[1])
-	if parts[1] == "gmail.com" || parts[1] == "googlemail.com" {
-		parts[1] = "gmail.com"
-		parts[0] = strings.Split(ReplacePattern(parts[0], `\.`, ""), "+")[0]
+	a, err := ParseEmail(str)
+	if err != nil {
+		return "", err
 	}
-	return strings.Join(parts, "@"), nil
+
+	a.Domain = strings.TrimSuffix(a.Domain, ".")
+	if a.Domain == "gmail.com" || a.Domain == "googlemail.com" {
+		a.Domain = "gmail.com"
+		a.Local = strings.Split(ReplacePattern(strings.ToLower(a.Local), `\.`, ""), "+")[0]
+	}
+	return a.String(), nil
 }
 
 // Truncate a string to the closest length without breaking words.
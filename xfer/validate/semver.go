@@ -0,0 +1,212 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semver is a parsed semantic version. Build metadata is dropped, as it doesn't count in comparisons.
+type semver struct {
+	major, minor, patch uint64
+	pre                 []string
+}
+
+// parseSemver parses a semantic version, with or without a leading v
+func parseSemver(str string) (semver, bool) {
+	var v semver
+	str = strings.TrimSpace(str)
+	if !IsSemver(str) {
+		return v, false
+	}
+
+	str = strings.TrimPrefix(str, "v")
+	if idx := strings.Index(str, "+"); idx >= 0 {
+		str = str[:idx]
+	}
+	if idx := strings.Index(str, "-"); idx >= 0 {
+		v.pre = strings.Split(str[idx+1:], ".")
+		str = str[:idx]
+	}
+
+	parts := strings.Split(str, ".")
+	for i, n := range []*uint64{&v.major, &v.minor, &v.patch} {
+		var err error
+		if *n, err = strconv.ParseUint(parts[i], 10, 64); err != nil {
+			return v, false
+		}
+	}
+	return v, true
+}
+
+// compare returns -1, 0 or 1 as v has a lower, the same or a higher precedence than o
+func (v semver) compare(o semver) int {
+	for _, pair := range [][2]uint64{{v.major, o.major}, {v.minor, o.minor}, {v.patch, o.patch}} {
+		if pair[0] != pair[1] {
+			return compareUint(pair[0], pair[1])
+		}
+	}
+
+	// a pre-release comes before its release
+	switch {
+	case len(v.pre) == 0 && len(o.pre) == 0:
+		return 0
+	case len(v.pre) == 0:
+		return 1
+	case len(o.pre) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.pre) && i < len(o.pre); i++ {
+		if c := comparePreRelease(v.pre[i], o.pre[i]); c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(v.pre)), uint64(len(o.pre)))
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparePreRelease compares pre-release identifiers: numbers numerically and before other identifiers, which
+// compare in ASCII order
+func comparePreRelease(a, b string) int {
+	na, errA := strconv.ParseUint(a, 10, 64)
+	nb, errB := strconv.ParseUint(b, 10, 64)
+	switch {
+	case errA == nil && errB == nil:
+		return compareUint(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// semverBound is a comparison a version must pass, such as >=1.2.0
+type semverBound struct {
+	op      string
+	version semver
+}
+
+func (b semverBound) allows(v semver) bool {
+	c := v.compare(b.version)
+	switch b.op {
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case "!=":
+		return c != 0
+	}
+	return c == 0
+}
+
+// semverOperators are the constraint operators, longest first so >= isn't read as >
+var semverOperators = []string{">=", "<=", "!=", ">", "<", "=", "~", "^"}
+
+// parseSemverConstraint parses a constraint made of space separated comparisons that must all pass, e.g.
+// ">=1.2.0 <2.0.0". Operators are =, !=, >, >=, < and <=, plus ~1.2.3 (>=1.2.3 <1.3.0) and ^1.2.3 (>=1.2.3
+// <2.0.0, or <0.3.0 for ^0.2.3). A version without an operator must match exactly.
+func parseSemverConstraint(str string) ([]semverBound, error) {
+	fields := strings.Fields(str)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("Empty semver constraint")
+	}
+
+	bounds := make([]semverBound, 0, len(fields))
+	for i := 0; i < len(fields); i++ {
+		op, version := "=", fields[i]
+		for _, candidate := range semverOperators {
+			if strings.HasPrefix(version, candidate) {
+				op, version = candidate, version[len(candidate):]
+				break
+			}
+		}
+		// the operator can be apart from its version: >= 1.2.0
+		if len(version) == 0 && i+1 < len(fields) {
+			i++
+			version = fields[i]
+		}
+
+		v, ok := parseSemver(version)
+		if !ok {
+			return nil, fmt.Errorf("Invalid semver constraint %q", str)
+		}
+
+		switch op {
+		case "~":
+			bounds = append(bounds, semverBound{">=", v}, semverBound{"<", semver{major: v.major, minor: v.minor + 1}})
+		case "^":
+			upper := semver{major: v.major + 1}
+			if v.major == 0 && v.minor > 0 {
+				upper = semver{minor: v.minor + 1}
+			} else if v.major == 0 {
+				upper = semver{minor: v.minor, patch: v.patch + 1}
+			}
+			bounds = append(bounds, semverBound{">=", v}, semverBound{"<", upper})
+		default:
+			bounds = append(bounds, semverBound{op, v})
+		}
+	}
+	return bounds, nil
+}
+
+// MatchSemver check if version satisfies one of the constraints (see semverrange). An error means a constraint is
+// invalid; versions that aren't semantic versions don't match.
+func MatchSemver(version string, constraints ...string) (bool, error) {
+	v, ok := parseSemver(version)
+
+	matched := false
+	for _, constraint := range constraints {
+		bounds, err := parseSemverConstraint(constraint)
+		if err != nil {
+			return false, err
+		}
+		if !ok || matched {
+			continue
+		}
+
+		matched = true
+		for _, bound := range bounds {
+			if !bound.allows(v) {
+				matched = false
+				break
+			}
+		}
+	}
+	return matched, nil
+}
+
+// isSemverRange is the semverrange validator, e.g. semverrange(>=1.2.0 <2.0.0). Each param is a constraint and
+// the version must satisfy one of them, so semverrange(^1.4.0,^2.1.0) accepts 1.x from 1.4.0 and 2.x from 2.1.0.
+// Empty values are ignored; use required for them.
+func isSemverRange(ctx context.Context, val interface{}, params ...interface{}) (bool, error) {
+	str, ok := val.(string)
+	if !ok {
+		return false, fmt.Errorf("semverrange only validates strings; got %T", val)
+	}
+	if len(params) == 0 {
+		return false, fmt.Errorf("semverrange needs a constraint, e.g. semverrange(>=1.2.0 <2.0.0)")
+	}
+
+	constraints := make([]string, len(params))
+	for i, param := range params {
+		constraints[i] = toString(param)
+	}
+	matched, err := MatchSemver(str, constraints...)
+	return matched || (err == nil && len(str) == 0), err
+}
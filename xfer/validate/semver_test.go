@@ -0,0 +1,98 @@
+package validate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSemverCompare(t *testing.T) {
+	t.Parallel()
+
+	// in increasing precedence, as in the semver spec
+	versions := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11",
+		"1.0.0-rc.1", "1.0.0", "1.0.1", "1.2.0", "1.10.0", "2.0.0"}
+	for i := range versions {
+		for j := range versions {
+			a, ok := parseSemver(versions[i])
+			assert.True(t, ok, versions[i])
+			b, _ := parseSemver(versions[j])
+			assert.Equal(t, compareUint(uint64(i), uint64(j)), a.compare(b), versions[i]+" "+versions[j])
+		}
+	}
+
+	a, _ := parseSemver("v1.2.3+build.5")
+	b, _ := parseSemver("1.2.3")
+	assert.Equal(t, 0, a.compare(b))
+
+	_, ok := parseSemver("1.2.99999999999999999999")
+	assert.False(t, ok)
+}
+
+func TestMatchSemver(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		version     string
+		constraints []string
+		expected    bool
+	}{
+		{"1.2.0", []string{">=1.2.0 <2.0.0"}, true},
+		{"1.9.9", []string{">=1.2.0 <2.0.0"}, true},
+		{"2.0.0", []string{">=1.2.0 <2.0.0"}, false},
+		{"1.1.9", []string{">=1.2.0 <2.0.0"}, false},
+		{"1.5.0", []string{">= 1.2.0 < 2.0.0"}, true},
+		{"1.2.3", []string{"1.2.3"}, true},
+		{"v1.2.3", []string{"=1.2.3"}, true},
+		{"1.2.4", []string{"1.2.3"}, false},
+		{"1.2.4", []string{">1.2.3 !=1.2.5"}, true},
+		{"1.2.5", []string{">1.2.3 !=1.2.5"}, false},
+		{"1.2.9", []string{"~1.2.3"}, true},
+		{"1.3.0", []string{"~1.2.3"}, false},
+		{"1.9.0", []string{"^1.2.3"}, true},
+		{"2.0.0", []string{"^1.2.3"}, false},
+		{"0.2.9", []string{"^0.2.3"}, true},
+		{"0.3.0", []string{"^0.2.3"}, false},
+		{"0.0.3", []string{"^0.0.3"}, true},
+		{"0.0.4", []string{"^0.0.3"}, false},
+		{"1.5.0", []string{"^1.4.0", "^2.1.0"}, true},
+		{"2.0.0", []string{"^1.4.0", "^2.1.0"}, false},
+		{"2.3.0", []string{"^1.4.0", "^2.1.0"}, true},
+		{"2.0.0-rc.1", []string{">=1.2.0 <2.0.0"}, true},
+		{"latest", []string{">=1.2.0"}, false},
+	}
+	for _, test := range tests {
+		matched, err := MatchSemver(test.version, test.constraints...)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, matched, test.version)
+	}
+
+	for _, constraint := range []string{"", ">=1.2", "=>1.2.0", "^1.x", ">=1.2.0 <"} {
+		_, err := MatchSemver("1.2.0", constraint)
+		assert.NotNil(t, err, constraint)
+	}
+}
+
+func TestSemverRangeRule(t *testing.T) {
+	t.Parallel()
+
+	type Plugin struct {
+		Version string `json:"version" valid:"semverrange(>=1.2.0 <2.0.0)"`
+		API     string `json:"api" valid:"semverrange(^1.4.0,^2.1.0)|redact=false"`
+	}
+
+	bag, err := ValidateStruct(Plugin{Version: "1.4.2", API: "2.1.0"})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	bag, err = ValidateStruct(Plugin{})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	bag, err = ValidateStruct(Plugin{Version: "2.0.1", API: "1.3.0"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"version:semverrange", "api:semverrange"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "Version must be a supported version", bag.FirstErrorMessage())
+
+	_, err = ValidateVar("1.2.0", "semverrange(>=one)")
+	assert.NotNil(t, err)
+}
@@ -1,15 +1,25 @@
 package validate
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/emirpasic/gods/maps/hashmap"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 )
 
 var emKeyMap = hashmap.New()
 
+// messagesLocale is the locale used to render messages, set with SetMessagesLocale
+var messagesLocale = struct {
+	sync.RWMutex
+	locale string
+}{locale: DefaultLocale}
+
 var customMessageVarRegex = regexp.MustCompile("{.*?}")
 
 // Messages take precedence over MessageFmts
@@ -30,11 +40,50 @@ type EmValidator struct {
 	OpString                func(val string, params ...interface{}) bool
 	CanValidateComplexTypes bool
 
+	// OpContext is for validators that need a context, usually because they do IO (MX lookups, uniqueness queries).
+	// It takes precedence over Op and OpString. An error means the value couldn't be checked, and is returned as an
+	// internal error.
+	OpContext func(ctx context.Context, val interface{}, params ...interface{}) (bool, error)
+
+	// IsSlow marks IO-bound validators. They run once the other validators are done, concurrently and bounded by
+	// the validation context (see SetSlowValidatorWorkers), so they must be safe for concurrent use.
+	IsSlow bool
+
+	// ParamNames names the validator params, in order, so messages can refer to them as {min}, {max} etc.
+	ParamNames []string
+
+	// OpReasons is for validators that can fail in several ways: it returns why the value failed, and the value is
+	// valid if there's no reason. The reasons are rendered into the {reasons} placeholder of the validator message.
+	// It takes precedence over OpContext, and an error is returned as an internal error too.
+	OpReasons func(ctx context.Context, val interface{}, params ...interface{}) ([]Reason, error)
+
+	// Redact hides the value of the fields the validator checks, as the redact=true setting does, for validators of
+	// secrets
+	Redact bool
+
+	// FieldParams makes params that name another field of the struct stand for that field's value, so a rule can
+	// depend on a sibling field, e.g. cvc(CardNumber). Params that don't name a field are passed as is.
+	FieldParams bool
+
 	DefaultMessages         MessageSet
 	ValidatorCustomMessages MessageSet
 }
 
 func (ev EmValidator) Validate(v reflect.Value, params []interface{}) (bool, error) {
+	return ev.ValidateContext(context.Background(), v, params)
+}
+
+// ValidateContext is Validate with a context for validators that use OpContext
+func (ev EmValidator) ValidateContext(ctx context.Context, v reflect.Value, params []interface{}) (bool, error) {
+
+	if ev.OpReasons != nil {
+		reasons, err := ev.OpReasons(ctx, v.Interface(), params...)
+		return len(reasons) == 0, err
+	}
+
+	if ev.OpContext != nil {
+		return ev.OpContext(ctx, v.Interface(), params...)
+	}
 
 	if ev.Op != nil {
 		return ev.Op(v.Interface(), params...), nil
@@ -50,66 +99,336 @@ func (ev EmValidator) Validate(v reflect.Value, params []interface{}) (bool, err
 	return false, fmt.Errorf("No default validator for field of type %s", v.Type().Name())
 }
 
+// Reason is one of the reasons a value failed a validator (see EmValidator.OpReasons). Key is relative to the
+// validator key: the message of reason minlength of the password validator is password.minlength.message. Values
+// fill the message placeholders.
+type Reason struct {
+	Key    string
+	Values map[string]interface{}
+}
+
+// reasonsPlaceholder is the placeholder the reasons are rendered into
+const reasonsPlaceholder = "reasons"
+
+// reasonSeparator joins the rendered reasons in the {reasons} placeholder
+const reasonSeparator = ", "
+
+// renderReasons renders the messages of the reasons a value failed the validator with key validatorKey. Reasons
+// without a message are rendered as their key.
+func renderReasons(validatorKey string, reasons []Reason, locale string) string {
+	rendered := make([]string, len(reasons))
+	for i, reason := range reasons {
+		msg, found := localizedMessage(locale, validatorKey+"."+reason.Key, false, reason.Values)
+		if !found {
+			msg = reason.Key
+		}
+		rendered[i] = msg
+	}
+	return strings.Join(rendered, reasonSeparator)
+}
+
 type FieldValidator struct {
 	FieldName           string
+	ValidatorKey        string
 	FieldValue          interface{}
 	Validator           EmValidator
 	ValidatorParams     []interface{}
 	IsNegated           bool
+	IsRedacted          bool
 	FieldCustomMessages MessageSet
+
+	// parent is the struct holding the field, used for {other.Field} placeholders. It's invalid for ValidateVar.
+	parent reflect.Value
+
+	// label resolves the field name in the locale of the message (see LabelProvider)
+	label fieldLabel
+
+	// reasons are the reasons the value failed, for validators with OpReasons. They're set by check.
+	reasons []Reason
+
+	// otherFields are the fields of parent the messages refer to as {other.Field}, found by checkMessages
+	otherFields []string
 }
 
 func (ms FieldValidator) CanValidateComplexTypes() bool {
 	return ms.Validator.CanValidateComplexTypes
 }
 
+// check runs the validator on v, keeping the reasons of validators with OpReasons for the error
+func (ms *FieldValidator) check(ctx context.Context, v reflect.Value) (bool, error) {
+	if ms.Validator.OpReasons == nil {
+		return ms.Validator.ValidateContext(ctx, v, ms.resolvedParams())
+	}
+
+	reasons, err := ms.Validator.OpReasons(ctx, v.Interface(), ms.resolvedParams()...)
+	ms.reasons = reasons
+	return len(reasons) == 0, err
+}
+
+// Message renders the validator message in the default locale (see SetMessagesLocale)
 func (ms FieldValidator) Message() string {
+	return ms.MessageIn(currentMessagesLocale())
+}
+
+// MessageIn renders the validator message in the given locale. Custom messages from the tag are never translated.
+func (ms FieldValidator) MessageIn(locale string) string {
 
 	if len(ms.FieldCustomMessages.Message) > 0 {
-		return ms.fillMessagePlaceholders(ms.FieldCustomMessages.Message)
+		return fillMessagePlaceholders(ms.FieldCustomMessages.Message, ms.placeholderValues(locale), locale)
 	} else if len(ms.FieldCustomMessages.MessageFmt) > 0 {
-		return fmt.Sprintf(ms.FieldCustomMessages.MessageFmt, ms.FieldName)
+		return fmt.Sprintf(ms.FieldCustomMessages.MessageFmt, ms.labelIn(locale))
+	} else if len(ms.Validator.ValidatorCustomMessages.Message) > 0 {
+		return fillMessagePlaceholders(ms.Validator.ValidatorCustomMessages.Message, ms.placeholderValues(locale), locale)
+	} else if len(ms.Validator.ValidatorCustomMessages.MessageFmt) > 0 {
+		return fmt.Sprintf(ms.Validator.ValidatorCustomMessages.MessageFmt, ms.labelIn(locale))
+	} else if msg, found := localizedMessage(locale, ms.ValidatorKey, false, ms.placeholderValues(locale)); found {
+		return msg
 	} else if len(ms.Validator.DefaultMessages.Message) > 0 {
-		return ms.fillMessagePlaceholders(ms.Validator.DefaultMessages.Message)
+		return fillMessagePlaceholders(ms.Validator.DefaultMessages.Message, ms.placeholderValues(locale), locale)
 	}
 
-	return fmt.Sprintf(ms.Validator.DefaultMessages.MessageFmt, ms.FieldName)
+	return fmt.Sprintf(ms.Validator.DefaultMessages.MessageFmt, ms.labelIn(locale))
 }
 
-func (ms FieldValidator) fillMessagePlaceholders(msg string) string {
-	if strings.Index(msg, "{") < 0 {
-		return msg
+// labelIn returns the field label in locale. FieldName is used as is for validators that weren't created from a
+// struct tag.
+func (ms FieldValidator) labelIn(locale string) string {
+	if len(ms.label.field.Name) == 0 {
+		return ms.FieldName
+	}
+	return ms.label.in(locale)
+}
+
+// hasCustomMessage is true if the tag defines a message for the field, or SetCustomMessage one for the validator, in
+// which case the message is not translated
+func (ms FieldValidator) hasCustomMessage() bool {
+	return len(ms.FieldCustomMessages.Message) > 0 || len(ms.FieldCustomMessages.MessageFmt) > 0 ||
+		len(ms.Validator.ValidatorCustomMessages.Message) > 0 || len(ms.Validator.ValidatorCustomMessages.MessageFmt) > 0
+}
+
+// newError creates the error for a failed validation at path, with its message rendered in locale
+func (ms FieldValidator) newError(name, path, locale string) Error {
+	e := Error{
+		Name:      name,
+		Field:     ms.labelIn(locale),
+		Path:      path,
+		Validator: ms.ValidatorKey,
+		Params:    ms.namedParams(),
+		Value:     ms.FieldValue,
+		Redacted:  ms.IsRedacted,
+	}
+	if !ms.hasCustomMessage() {
+		e.MessageKey = ms.ValidatorKey
+	}
+	e.reasons = ms.reasons
+	for _, reason := range ms.reasons {
+		e.Reasons = append(e.Reasons, reason.Key)
+	}
+	if e.Redacted {
+		e.Value = RedactedValue
+	}
+	e.values = ms.placeholderValues(locale)
+	e.label = ms.label
+	e.Err = errors.New(ms.MessageIn(locale))
+	return e
+}
+
+// namedParams returns the validator params keyed by their names (see EmValidator.ParamNames), or by their index
+// for params without a name
+func (ms FieldValidator) namedParams() map[string]interface{} {
+	params := make(map[string]interface{}, len(ms.ValidatorParams))
+	for i, param := range ms.ValidatorParams {
+		if i < len(ms.Validator.ParamNames) {
+			params[ms.Validator.ParamNames[i]] = param
+		} else {
+			params[strconv.Itoa(i)] = param
+		}
 	}
-	replacements := map[string]interface{}{
-		"field": ms.FieldName,
-		"value": ms.FieldValue,
-	}
-	return customMessageVarRegex.ReplaceAllStringFunc(msg, func(val string) string {
-		key := val[1 : len(val)-1]
-		formatString := "%v"
-		parts := strings.Split(key, ":")
-		if len(parts) > 1 {
-			key = parts[0]
-			formatString = parts[1]
+	return params
+}
+
+// resolvedParams returns the params passed to the validator. For validators with FieldParams, params naming another
+// field of the struct are replaced with the field's value.
+func (ms FieldValidator) resolvedParams() []interface{} {
+	if !ms.Validator.FieldParams {
+		return ms.ValidatorParams
+	}
+	return resolveFieldParams(ms.parent, ms.ValidatorParams)
+}
+
+// resolveFieldParams replaces the params that name an exported field of parent with the field's value. Promoted
+// fields are not resolved. Nil pointers resolve to nil.
+func resolveFieldParams(parent reflect.Value, params []interface{}) []interface{} {
+	if !parent.IsValid() || parent.Kind() != reflect.Struct {
+		return params
+	}
+
+	resolved := make([]interface{}, len(params))
+	for i, param := range params {
+		resolved[i] = param
+		name, ok := param.(string)
+		if !ok {
+			continue
 		}
-		if replacement, found := replacements[key]; found {
-			return fmt.Sprintf(formatString, replacement)
+		typeField, ok := parent.Type().FieldByName(strings.TrimSpace(name))
+		if !ok || typeField.PkgPath != "" || len(typeField.Index) > 1 {
+			continue
+		}
+
+		field := parent.Field(typeField.Index[0])
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				resolved[i] = nil
+				continue
+			}
+			field = field.Elem()
 		}
-		return "???"
-	})
+		resolved[i] = field.Interface()
+	}
+	return resolved
+}
+
+// placeholderValues returns the values that can be used as {placeholders} in messages: the field label and value,
+// the validator params by name and by index, and the other fields of the struct the messages refer to as
+// {other.Field}. Redacted values are replaced with RedactedValue.
+func (ms FieldValidator) placeholderValues(locale string) map[string]interface{} {
+	value := ms.FieldValue
+	if ms.IsRedacted {
+		value = RedactedValue
+	}
+	values := messageValues(ms.labelIn(locale), value, ms.namedParams())
+
+	for i, param := range ms.ValidatorParams {
+		values[strconv.Itoa(i)] = param
+	}
+
+	if ms.Validator.OpReasons != nil {
+		values[reasonsPlaceholder] = renderReasons(ms.ValidatorKey, ms.reasons, locale)
+	}
+
+	if ms.parent.IsValid() && ms.parent.Kind() == reflect.Struct {
+		for _, name := range ms.otherFields {
+			typeField, _ := ms.parent.Type().FieldByName(name)
+			if isRedactedField(typeField) {
+				values[otherFieldPrefix+name] = RedactedValue
+			} else {
+				values[otherFieldPrefix+name] = ms.parent.FieldByIndex(typeField.Index).Interface()
+			}
+		}
+	}
+
+	return values
+}
+
+// placeholderNames returns the names placeholderValues has values for, with every exported field of the struct
+// as {other.Field}
+func (ms FieldValidator) placeholderNames() map[string]bool {
+	names := map[string]bool{"field": true, "value": true}
+	for name := range ms.namedParams() {
+		names[name] = true
+	}
+	for i := range ms.ValidatorParams {
+		names[strconv.Itoa(i)] = true
+	}
+	if ms.Validator.OpReasons != nil {
+		names[reasonsPlaceholder] = true
+	}
+	if ms.parent.IsValid() && ms.parent.Kind() == reflect.Struct {
+		for i := 0; i < ms.parent.NumField(); i++ {
+			if typeField := ms.parent.Type().Field(i); typeField.PkgPath == "" {
+				names[otherFieldPrefix+typeField.Name] = true
+			}
+		}
+	}
+	return names
+}
+
+// checkMessages returns an error if one of the messages the validator can render refers to a placeholder that
+// doesn't exist for this field, so typos in messages fail when the tag is parsed instead of showing up in a
+// rendered message. It returns the fields the messages refer to as {other.Field}, so placeholderValues only reads
+// those.
+func (ms FieldValidator) checkMessages() ([]string, error) {
+	names := ms.placeholderNames()
+
+	templates := []string{
+		ms.FieldCustomMessages.Message,
+		ms.FieldCustomMessages.NegatedMessage,
+		ms.Validator.ValidatorCustomMessages.Message,
+		ms.Validator.ValidatorCustomMessages.NegatedMessage,
+		ms.Validator.DefaultMessages.Message,
+		ms.Validator.DefaultMessages.NegatedMessage,
+	}
+	templates = append(templates, bundles.templates(ms.ValidatorKey+".message", ms.ValidatorKey+".negatedmessage")...)
+
+	otherFields := make([]string, 0)
+	for _, msg := range templates {
+		referenced, err := checkMessagePlaceholders(msg, names)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid message for validator %s on field %s: %s", ms.ValidatorKey, ms.FieldName, err.Error())
+		}
+		for _, name := range referenced {
+			if strings.HasPrefix(name, otherFieldPrefix) && !containsString(otherFields, name[len(otherFieldPrefix):]) {
+				otherFields = append(otherFields, name[len(otherFieldPrefix):])
+			}
+		}
+	}
+
+	return otherFields, nil
+}
+
+// messageValues merges the field name, value and validator params into the values used to fill message placeholders
+func messageValues(field string, value interface{}, params map[string]interface{}) map[string]interface{} {
+	values := make(map[string]interface{}, len(params)+2)
+	for name, param := range params {
+		values[name] = param
+	}
+	values["field"] = field
+	values["value"] = value
+	return values
+}
+
+// localizedMessage renders the bundle message for a message key (the validator key) in the given locale, if
+// there is one
+func localizedMessage(locale, messageKey string, negated bool, values map[string]interface{}) (string, bool) {
+
+	keys := []string{messageKey + ".message", messageKey + ".messagefmt"}
+	if negated {
+		keys = []string{messageKey + ".negatedmessage", messageKey + ".negatedmessagefmt"}
+	}
+
+	msg, key, found := bundles.lookup(locale, keys...)
+	if !found {
+		return ``, false
+	}
+
+	text := msg.Text
+	if msg.Plural != nil {
+		count, _ := toFloat(toString(values[msg.Count]))
+		text = msg.form(bundles.pluralCategory(locale, count))
+	}
+
+	if strings.HasSuffix(key, "fmt") {
+		return fmt.Sprintf(text, values["field"]), true
+	}
+	return fillMessagePlaceholders(text, values, locale), true
 }
 
 func (ms FieldValidator) NegatedMessage() string {
 
+	locale := currentMessagesLocale()
 	if len(ms.FieldCustomMessages.NegatedMessage) > 0 {
 		return ms.FieldCustomMessages.Message
 	} else if len(ms.FieldCustomMessages.NegatedMessageFmt) > 0 {
-		return fmt.Sprintf(ms.FieldCustomMessages.MessageFmt, ms.FieldName)
+		return fmt.Sprintf(ms.FieldCustomMessages.MessageFmt, ms.labelIn(locale))
+	} else if len(ms.Validator.ValidatorCustomMessages.NegatedMessage) > 0 {
+		return fillMessagePlaceholders(ms.Validator.ValidatorCustomMessages.NegatedMessage, ms.placeholderValues(locale), locale)
+	} else if msg, found := localizedMessage(locale, ms.ValidatorKey, true, ms.placeholderValues(locale)); found {
+		return msg
 	} else if len(ms.Validator.DefaultMessages.NegatedMessage) > 0 {
 		return ms.Validator.DefaultMessages.Message
 	}
 
-	return fmt.Sprintf(ms.Validator.DefaultMessages.NegatedMessageFmt, ms.FieldName)
+	return fmt.Sprintf(ms.Validator.DefaultMessages.NegatedMessageFmt, ms.labelIn(locale))
 }
 
 func GetValidator(key string) (*EmValidator, bool) {
@@ -122,21 +441,43 @@ func GetValidator(key string) (*EmValidator, bool) {
 	return nil, found
 }
 
+// RegisterValidator adds a validator, or replaces the one registered with the same key. It must be called before
+// validating, usually in init() methods.
+func RegisterValidator(key string, ev EmValidator) error {
+	if len(key) == 0 || strings.ContainsAny(key, validatorSeparator+settingsToken+paramOpenToken+paramCloseToken+"!") {
+		return fmt.Errorf("%q is not a valid validator key", key)
+	}
+	if ev.Op == nil && ev.OpString == nil && ev.OpContext == nil {
+		return fmt.Errorf("Validator %s has no Op", key)
+	}
+
+	emKeyMap.Put(key, &ev)
+	resetMessageChecks()
+	return nil
+}
+
+// SetCustomMessage sets the message of a validator, which takes precedence over the bundle messages but not over the
+// messages set in tags. It's never translated. Messages with %s are formatted with the field name; other messages
+// can use the same {placeholders} as messages in tags.
 func SetCustomMessage(key string, msg string) error {
 	v, exists := GetValidator(key)
 	if !exists {
 		return fmt.Errorf("Validator with key %s doesn't exist", key)
 	}
+	if err := checkPlaceholderSyntax(msg); err != nil {
+		return fmt.Errorf("Invalid message for validator %s: %s", key, err.Error())
+	}
 
 	ms := MessageSet{}
 
-	if strings.Index(key, "%s") > -1 {
+	if strings.Index(msg, "%s") > -1 {
 		ms.MessageFmt = msg
 	} else {
 		ms.Message = msg
 	}
 
 	v.ValidatorCustomMessages = ms
+	resetMessageChecks()
 
 	return nil
 }
@@ -146,16 +487,20 @@ func SetCustomNegationMessage(key string, msg string) error {
 	if !exists {
 		return fmt.Errorf("Validator with key %s doesn't exist", key)
 	}
+	if err := checkPlaceholderSyntax(msg); err != nil {
+		return fmt.Errorf("Invalid message for validator %s: %s", key, err.Error())
+	}
 
 	ms := MessageSet{}
 
-	if strings.Index(key, "%s") > -1 {
+	if strings.Index(msg, "%s") > -1 {
 		ms.NegatedMessageFmt = msg
 	} else {
 		ms.NegatedMessage = msg
 	}
 
 	v.ValidatorCustomMessages = ms
+	resetMessageChecks()
 
 	return nil
 }
@@ -164,8 +509,14 @@ func SetCustomMessages(key string, ms MessageSet) error {
 	if !exists {
 		return fmt.Errorf("Validator with key %s doesn't exist", key)
 	}
+	for _, msg := range []string{ms.Message, ms.NegatedMessage} {
+		if err := checkPlaceholderSyntax(msg); err != nil {
+			return fmt.Errorf("Invalid message for validator %s: %s", key, err.Error())
+		}
+	}
 
 	v.ValidatorCustomMessages = ms
+	resetMessageChecks()
 
 	return nil
 }
@@ -177,18 +528,35 @@ func ClearCustomMessages(validationKey string) {
 	}
 
 	v.ValidatorCustomMessages = MessageSet{}
+	resetMessageChecks()
 }
 
 func init() {
 	emKeyMap.Clear()
-	emKeyMap.Put("required", &EmValidator{Op: IsNonEmpty, CanValidateComplexTypes: true, DefaultMessages: MessageSet{Message: "{field} must not be empty"}})
-	emKeyMap.Put("between", &EmValidator{Op: Between, DefaultMessages: MessageSet{Message: "{field} is out of range"}})
+	emKeyMap.Put("required", &EmValidator{Op: IsNonEmpty, CanValidateComplexTypes: true})
+	emKeyMap.Put("between", &EmValidator{Op: Between, ParamNames: []string{"min", "max"}})
+	emKeyMap.Put("positive", &EmValidator{Op: isPositiveNumber})
+	emKeyMap.Put("nonnegative", &EmValidator{Op: isNonNegativeNumber})
+	emKeyMap.Put("negative", &EmValidator{Op: isNegativeNumber})
+	emKeyMap.Put("nonpositive", &EmValidator{Op: isNonPositiveNumber})
+	emKeyMap.Put("whole", &EmValidator{Op: isWholeNumber})
+	emKeyMap.Put("natural", &EmValidator{Op: isNaturalNumber})
+	emKeyMap.Put("divisibleby", &EmValidator{OpContext: isDivisibleBy, ParamNames: []string{"divisor"}})
+	emKeyMap.Put("multipleof", &EmValidator{OpContext: isMultipleOf, ParamNames: []string{"step"}})
 	emKeyMap.Put("matches", &EmValidator{OpString: StringMatches}) // can't use random regexes in
 	emKeyMap.Put("title", &EmValidator{OpString: IsTitle})
 	emKeyMap.Put("name", &EmValidator{OpString: IsName})
-	emKeyMap.Put("phone", &EmValidator{OpString: IsPhone})
+	emKeyMap.Put("phone", &EmValidator{OpContext: isPhone, FieldParams: true})
 	emKeyMap.Put("skype", &EmValidator{OpString: IsSkype})
+	emKeyMap.Put("password", &EmValidator{OpReasons: passwordReasons, IsSlow: true, Redact: true, FieldParams: true})
+	emKeyMap.Put("maxsize", &EmValidator{OpContext: isMaxSize, ParamNames: []string{"size"}})
+	emKeyMap.Put("mimes", &EmValidator{OpContext: isMimes})
+	emKeyMap.Put("dimensions", &EmValidator{OpContext: isDimensions, ParamNames: []string{"minw", "minh", "maxw", "maxh"}})
+	emKeyMap.Put("ratio", &EmValidator{OpContext: isRatio, ParamNames: []string{"ratio"}})
+	emKeyMap.Put("safehtml", &EmValidator{OpContext: isSafeHTML})
 	emKeyMap.Put("email", &EmValidator{OpString: IsEmail})
+	emKeyMap.Put("nodisposable", &EmValidator{OpString: IsNotDisposableEmail})
+	emKeyMap.Put("mx", &EmValidator{OpContext: isMXEmail, IsSlow: true})
 	emKeyMap.Put("url", &EmValidator{OpString: IsURL})
 	emKeyMap.Put("dialstring", &EmValidator{OpString: IsDialString})
 	emKeyMap.Put("requrl", &EmValidator{OpString: IsRequestURL})
@@ -212,9 +580,17 @@ func init() {
 	emKeyMap.Put("uuidv3", &EmValidator{OpString: IsUUIDv3})
 	emKeyMap.Put("uuidv4", &EmValidator{OpString: IsUUIDv4})
 	emKeyMap.Put("uuidv5", &EmValidator{OpString: IsUUIDv5})
+	emKeyMap.Put("ulid", &EmValidator{OpString: IsULID})
+	emKeyMap.Put("ksuid", &EmValidator{OpString: IsKSUID})
+	emKeyMap.Put("slug", &EmValidator{OpString: IsSlug})
 	emKeyMap.Put("isoalpha2", &EmValidator{OpString: IsISO3166Alpha2})
 	emKeyMap.Put("isoalpha3", &EmValidator{OpString: IsISO3166Alpha3})
 	emKeyMap.Put("creditcard", &EmValidator{OpString: IsCreditCard})
+	emKeyMap.Put("cardexpiry", &EmValidator{OpString: IsCardExpiry})
+	emKeyMap.Put("cvc", &EmValidator{OpString: IsCVC, FieldParams: true})
+	emKeyMap.Put("iban", &EmValidator{OpString: IsIBAN})
+	emKeyMap.Put("bic", &EmValidator{OpString: IsBIC})
+	emKeyMap.Put("vatid", &EmValidator{OpString: IsVATID})
 	emKeyMap.Put("isbn10", &EmValidator{OpString: IsISBN10})
 	emKeyMap.Put("isbn13", &EmValidator{OpString: IsISBN13})
 	emKeyMap.Put("json", &EmValidator{OpString: IsJSON})
@@ -224,18 +600,29 @@ func init() {
 	emKeyMap.Put("fullwidth", &EmValidator{OpString: IsFullWidth})
 	emKeyMap.Put("halfwidth", &EmValidator{OpString: IsHalfWidth})
 	emKeyMap.Put("variablewidth", &EmValidator{OpString: IsVariableWidth})
+	emKeyMap.Put("printable", &EmValidator{OpString: IsPrintable})
+	emKeyMap.Put("singlescript", &EmValidator{OpString: IsSingleScript})
+	emKeyMap.Put("noconfusable", &EmValidator{OpString: IsNotConfusable})
 	emKeyMap.Put("base64", &EmValidator{OpString: IsBase64})
 	emKeyMap.Put("datauri", &EmValidator{OpString: IsDataURI})
 	emKeyMap.Put("ip", &EmValidator{OpString: IsIP})
 	emKeyMap.Put("port", &EmValidator{Op: IsPort})
 	emKeyMap.Put("ipv4", &EmValidator{OpString: IsIPv4})
+	emKeyMap.Put("ipv6", &EmValidator{Op: isIPv6})
+	emKeyMap.Put("cidr", &EmValidator{OpString: IsCIDR})
+	emKeyMap.Put("ipin", &EmValidator{OpContext: isIPIn})
+	emKeyMap.Put("publicip", &EmValidator{OpString: IsPublicIP})
 	emKeyMap.Put("dns", &EmValidator{OpString: IsDNSName})
 	emKeyMap.Put("host", &EmValidator{OpString: IsHost})
 	emKeyMap.Put("mac", &EmValidator{OpString: IsMAC})
-	emKeyMap.Put("latitude", &EmValidator{OpString: IsLatitude})
-	emKeyMap.Put("longitude", &EmValidator{OpString: IsLongitude})
+	emKeyMap.Put("latitude", &EmValidator{Op: isLatitude})
+	emKeyMap.Put("longitude", &EmValidator{Op: isLongitude})
+	emKeyMap.Put("latlng", &EmValidator{Op: isLatLngPair, CanValidateComplexTypes: true})
+	emKeyMap.Put("postcode", &EmValidator{OpContext: isPostcode, FieldParams: true})
 	emKeyMap.Put("ssn", &EmValidator{OpString: IsSSN})
 	emKeyMap.Put("semver", &EmValidator{OpString: IsSemver})
+	emKeyMap.Put("semverrange", &EmValidator{OpContext: isSemverRange})
+	emKeyMap.Put("mongoid", &EmValidator{Op: isMongoID})
 
 	err := SetMessagesLocale(`en`)
 	if err != nil {
@@ -243,55 +630,48 @@ func init() {
 	}
 }
 
+// SetMessagesLocale sets the default locale for validation messages, used when validating without a locale (see
+// ValidateStructContext). Messages missing from the locale's bundle fall back to its parent locales and finally to
+// DefaultLocale (de-AT -> de -> en). It's an error to set a locale that has no bundle for itself or one of its
+// parents.
+//
+// Validators are shared by every goroutine, so this only changes the locale; messages are rendered when a validation
+// fails.
 func SetMessagesLocale(locale string) error {
 
-	var messageMap map[string]string
-
-	switch locale {
-	case `en`:
-		messageMap = messages_en
-	default:
+	locale = normalizeLocale(locale)
+	if !bundles.supports(locale) {
 		return fmt.Errorf("Locale %s is not implemented (we'd love it if you could help us fix that!)", locale)
 	}
 
-	// extract parts from the compound key format
-	var keyRoot = func(fullKey string) string {
-		idx := strings.Index(fullKey, ".")
-		return fullKey[:idx]
-	}
+	messagesLocale.Lock()
+	messagesLocale.locale = locale
+	messagesLocale.Unlock()
 
-	var keyMsgType = func(fullKey string) string {
-		idx := strings.Index(fullKey, ".")
-		return fullKey[idx+1:]
-	}
+	return nil
+}
 
-	// set messages
-	for key, msg := range messageMap {
+func currentMessagesLocale() string {
+	messagesLocale.RLock()
+	defer messagesLocale.RUnlock()
+	return messagesLocale.locale
+}
 
-		v, exists := emKeyMap.Get(keyRoot(key))
-		if !exists {
-			continue
-		}
+type localeContextKey struct{}
 
-		validator, _ := v.(*EmValidator)
-
-		messageType := keyMsgType(key)
-
-		switch messageType {
-		case `messagefmt`:
-			validator.DefaultMessages.MessageFmt = msg
-		case `negatedmessagefmt`:
-			validator.DefaultMessages.NegatedMessageFmt = msg
-		case `message`:
-			validator.DefaultMessages.Message = msg
-		case `negatedmessage`:
-			validator.DefaultMessages.NegatedMessage = msg
-		default:
-			return fmt.Errorf("%s is not a valid message type identifier", messageType)
+// WithLocale returns a context carrying the locale used for validation messages
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, normalizeLocale(locale))
+}
+
+// LocaleFromContext returns the locale set with WithLocale, or the default locale if there is none
+func LocaleFromContext(ctx context.Context) string {
+	if ctx != nil {
+		if locale, ok := ctx.Value(localeContextKey{}).(string); ok && len(locale) > 0 {
+			return locale
 		}
 	}
-
-	return nil
+	return currentMessagesLocale()
 }
 
 // ISO3166Entry stores country codes
@@ -303,7 +683,7 @@ type ISO3166Entry struct {
 	Numeric          string
 }
 
-//ISO3166List based on https://www.iso.org/obp/ui/#search/code/ Code Type "Officially Assigned Codes"
+// ISO3166List based on https://www.iso.org/obp/ui/#search/code/ Code Type "Officially Assigned Codes"
 var ISO3166List = []ISO3166Entry{
 	{"Afghanistan", "Afghanistan (l')", "AF", "AFG", "004"},
 	{"Albania", "Albanie (l')", "AL", "ALB", "008"},
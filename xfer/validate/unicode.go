@@ -0,0 +1,209 @@
+package validate
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+//go:generate go run gen_confusables.go
+
+// invisibleChars print as nothing but aren't whitespace: bidi controls, which reorder the text around them,
+// zero-width spaces and joiners, and fillers such as the Hangul filler, which are letters to unicode.IsLetter
+var invisibleChars = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00ad, Hi: 0x00ad, Stride: 1}, // soft hyphen
+		{Lo: 0x034f, Hi: 0x034f, Stride: 1}, // combining grapheme joiner
+		{Lo: 0x061c, Hi: 0x061c, Stride: 1}, // Arabic letter mark
+		{Lo: 0x115f, Hi: 0x1160, Stride: 1}, // Hangul fillers
+		{Lo: 0x17b4, Hi: 0x17b5, Stride: 1}, // Khmer inherent vowels
+		{Lo: 0x180b, Hi: 0x180e, Stride: 1}, // Mongolian variation selectors and vowel separator
+		{Lo: 0x200b, Hi: 0x200f, Stride: 1}, // zero-width space, non-joiner and joiner, LRM and RLM
+		{Lo: 0x202a, Hi: 0x202e, Stride: 1}, // bidi embeddings and overrides
+		{Lo: 0x2060, Hi: 0x206f, Stride: 1}, // word joiner, invisible operators, bidi isolates
+		{Lo: 0x2800, Hi: 0x2800, Stride: 1}, // blank braille pattern
+		{Lo: 0x3164, Hi: 0x3164, Stride: 1}, // Hangul filler
+		{Lo: 0xfeff, Hi: 0xfeff, Stride: 1}, // zero-width no-break space (BOM)
+		{Lo: 0xffa0, Hi: 0xffa0, Stride: 1}, // halfwidth Hangul filler
+	},
+	LatinOffset: 1,
+}
+
+// NFC returns the string in Unicode normalization form C, where characters are composed when they can be, so that
+// é is the same whether it was typed as one character or as e and a combining accent. It's the nfc sanitizer.
+func NFC(str string, params ...interface{}) string {
+	return norm.NFC.String(str)
+}
+
+// IsPrintable check if the string only holds printable characters and whitespace. Control characters, bidi
+// controls and zero-width characters, which can hide or reorder text, aren't printable. Empty string is valid.
+func IsPrintable(str string, params ...interface{}) bool {
+	if IsNull(str) {
+		return true
+	}
+	if !utf8.ValidString(str) {
+		return false
+	}
+	for _, r := range str {
+		if unicode.Is(invisibleChars, r) || !(unicode.IsGraphic(r) || unicode.IsSpace(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// augmentedScripts are the scripts a script is written with, as in UTS #39: Japanese mixes Han with Hiragana and
+// Katakana, Korean Han with Hangul, and Chinese Han with Bopomofo
+var augmentedScripts = map[string][]string{
+	"Han":      {"Han", "Jpan", "Kore", "Hanb"},
+	"Hiragana": {"Jpan"},
+	"Katakana": {"Jpan"},
+	"Hangul":   {"Kore"},
+	"Bopomofo": {"Hanb"},
+}
+
+// scriptOf returns the name of the script of r, as in unicode.Scripts
+func scriptOf(r rune) string {
+	if r < utf8.RuneSelf {
+		if 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' {
+			return "Latin"
+		}
+		return "Common"
+	}
+	for name, table := range unicode.Scripts {
+		if unicode.Is(table, r) {
+			return name
+		}
+	}
+	return "Unknown"
+}
+
+// IsSingleScript check if the letters of the string are all of one script, such as Latin, Cyrillic or Greek.
+// Digits, punctuation and combining marks are shared by all scripts and don't count, and Han may be mixed with
+// the scripts written with it in Japanese, Korean and Chinese. Empty string is valid.
+func IsSingleScript(str string, params ...interface{}) bool {
+	var scripts []string
+	for _, r := range str {
+		script := scriptOf(r)
+		if script == "Common" || script == "Inherited" || script == "Unknown" {
+			continue
+		}
+
+		augmented, ok := augmentedScripts[script]
+		if !ok {
+			augmented = []string{script}
+		}
+		if scripts == nil {
+			scripts = augmented
+			continue
+		}
+
+		common := make([]string, 0, len(scripts))
+		for _, s := range scripts {
+			if containsString(augmented, s) {
+				common = append(common, s)
+			}
+		}
+		if len(common) == 0 {
+			return false
+		}
+		scripts = common
+	}
+	return true
+}
+
+// isASCIILookalike is true if r is a letter or digit that isn't ASCII but is a compatibility variant of ASCII
+// letters or digits, such as fullwidth and mathematical letters or ligatures. Punctuation and symbols with an ASCII
+// decomposition, such as … or ™, aren't lookalikes.
+func isASCIILookalike(r rune) bool {
+	if r < utf8.RuneSelf || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+		return false
+	}
+	for _, c := range norm.NFKC.String(string(r)) {
+		if c >= utf8.RuneSelf || !(unicode.IsLetter(c) || unicode.IsDigit(c)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Skeleton returns the string with the characters that look like ASCII ones replaced by them, as in UTS #39.
+// Strings with the same skeleton look alike, e.g. "pаypal" with a Cyrillic а and "paypal"; compare the skeletons
+// of user names to stop one passing for another.
+func Skeleton(str string) string {
+	runes := make([]rune, 0, len(str))
+	for _, r := range norm.NFKC.String(str) {
+		if prototype, ok := confusables[r]; ok {
+			r = prototype
+		}
+		runes = append(runes, r)
+	}
+	return string(runes)
+}
+
+// IsConfusable is true if the string could pass for another one. It holds compatibility variants of ASCII, such as
+// fullwidth letters, or letters of other scripts that look like Latin ones mixed with Latin letters. Strings whose
+// letters all look like Latin ones, such as "рау" in Cyrillic, are confusable too; other text in those scripts
+// isn't.
+func IsConfusable(str string) bool {
+	latin, lookalikes, letters := false, 0, 0
+	for _, r := range norm.NFC.String(str) {
+		if isASCIILookalike(r) {
+			return true
+		}
+		if _, ok := confusables[r]; ok {
+			lookalikes++
+		} else if unicode.IsLetter(r) && unicode.Is(unicode.Latin, r) {
+			latin = true
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return lookalikes > 0 && (latin || lookalikes == letters)
+}
+
+// IsNotConfusable is the noconfusable validator. Empty values are ignored; use required for them.
+func IsNotConfusable(str string, params ...interface{}) bool {
+	return len(str) == 0 || !IsConfusable(str)
+}
+
+// ContainsOnly check if the string, normalized to NFC, only holds runes allowed accepts. Combining marks that don't
+// compose with the letter before them must be accepted too, so that a set of letters doesn't let "q̇" or Zalgo text
+// through; use ContainsOnlyLettersOr to accept letters of any script. Invisible characters are never accepted. Empty
+// string is valid.
+func ContainsOnly(str string, allowed func(r rune) bool) bool {
+	return containsOnly(str, allowed, false)
+}
+
+// ContainsOnlyLettersOr check if the string, normalized to NFC, only holds letters and runes allowed accepts.
+// Combining marks are accepted after a letter, so that letters written with marks that don't compose with them, as
+// in most Indic scripts, count as letters. allowed may be nil. Invisible characters are never accepted. Empty
+// string is valid.
+func ContainsOnlyLettersOr(str string, allowed func(r rune) bool) bool {
+	return containsOnly(str, func(r rune) bool {
+		return unicode.IsLetter(r) || allowed != nil && allowed(r)
+	}, true)
+}
+
+// containsOnly is ContainsOnly, accepting combining marks after letters if marks is set
+func containsOnly(str string, allowed func(r rune) bool, marks bool) bool {
+	if !utf8.ValidString(str) {
+		return false
+	}
+	afterLetter := false
+	for _, r := range norm.NFC.String(str) {
+		switch {
+		case unicode.Is(invisibleChars, r):
+			return false
+		case marks && afterLetter && unicode.IsMark(r):
+			continue
+		case allowed(r):
+			afterLetter = unicode.IsLetter(r)
+		default:
+			return false
+		}
+	}
+	return true
+}
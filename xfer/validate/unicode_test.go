@@ -0,0 +1,182 @@
+package validate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+func TestNFC(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Jos\u00e9", NFC("Jos\u00e9"))
+	assert.Equal(t, "Jos\u00e9", NFC("Jose\u0301"))
+	assert.Equal(t, "\uff41", NFC("\uff41")) // fullwidth a is left to noconfusable
+
+	type Account struct {
+		Name string `json:"name" sanitize:"trim|nfc" valid:"utfletter"`
+	}
+	account := Account{Name: " Jose\u0301 "}
+	assert.Nil(t, SanitizeStruct(&account))
+	assert.Equal(t, "Jos\u00e9", account.Name)
+}
+
+func TestIsPrintable(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		expected bool
+	}{
+		{"", true},
+		{"Hello, World!", true},
+		{"Grüße aus Köln\nund Zürich\t😀", true},
+		{"日本語のテキスト", true},
+		{"नमस्ते", true},
+		{"a\x00b", false},
+		{"a\x1bb", false},
+		{"a\u200bb", false},
+		{"a\u200db", false},
+		{"a\ufeffb", false},
+		{"a\u00adb", false},
+		{"invoice_\u202efdp.exe", false},
+		{"a\u2066b\u2069", false},
+		{"\u3164", false},
+		{"a\xffb", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsPrintable(test.param), test.param)
+	}
+}
+
+func TestIsSingleScript(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param    string
+		expected bool
+	}{
+		{"", true},
+		{"12-34!", true},
+		{"paypal", true},
+		{"Zoë_1990", true},
+		{"Привет", true},
+		{"Ελλάδα", true},
+		{"p\u0430ypal", false},
+		{"Hello Мир", false},
+		{"東京タワーとすし", true},
+		{"한국어 漢字", true},
+		{"ひらがな한글", false},
+		{"Jose\u0301", true},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsSingleScript(test.param), test.param)
+	}
+}
+
+func TestConfusables(t *testing.T) {
+	t.Parallel()
+
+	for source, prototype := range confusables {
+		assert.True(t, source >= utf8.RuneSelf, string(source))
+		assert.True(t, unicode.IsLetter(source) || unicode.IsDigit(source), string(source))
+		assert.True(t, prototype < utf8.RuneSelf, string(source))
+		assert.True(t, unicode.IsLetter(prototype) || unicode.IsDigit(prototype), string(source))
+	}
+
+	var tests = []struct {
+		param    string
+		expected bool
+	}{
+		{"", false},
+		{"paypal", false},
+		{"Zoë", false},
+		{"Привет", false},
+		{"Ελλάδα", false},
+		{"p\u0430ypal", true},
+		{"\u0440\u0430\u0443\u0440\u0430l", true},
+		{"\u0440\u0430\u0443", true},
+		{"ｐａｙｐａｌ", true},
+		{"\U0001d429aypal", true},
+		{"oﬃce", true},
+		{"p\u03b1ypal", true},
+		{"p\u0251yp\u0251l", true},
+		{"\u0261oogle", true},
+		{"pa\u0131d", true},
+		{"\ua4d0ank", true},
+		{"\u13a5nfo", true},
+		{"John\u00a0Smith", false},
+		{"Wait\u2026", false},
+		{"Acme\u2122", false},
+		{"m\u00b2", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsConfusable(test.param), test.param)
+		assert.Equal(t, test.expected, !IsNotConfusable(test.param), test.param)
+	}
+
+	assert.Equal(t, "paypal", Skeleton("p\u0430ypal"))
+	assert.Equal(t, "paypal", Skeleton("ｐａｙｐａｌ"))
+	assert.Equal(t, Skeleton("pay"), Skeleton("\u0440\u0430\u0443"))
+	assert.NotEqual(t, Skeleton("paypal"), Skeleton("paypa1"))
+}
+
+func TestContainsOnly(t *testing.T) {
+	t.Parallel()
+
+	letters := func(r rune) bool { return unicode.IsLetter(r) }
+	assert.True(t, ContainsOnly("", letters))
+	assert.True(t, ContainsOnly("Jose\u0301", letters))
+	assert.False(t, ContainsOnly("\u0928\u092e\u0938\u094d\u0924\u0947", letters))
+	assert.False(t, ContainsOnly("ab\u200bc", letters))
+	assert.False(t, ContainsOnly("ab\xff", func(r rune) bool { return true }))
+
+	vowels := func(r rune) bool { return r == 'a' || r == 'e' || r == '\u00e9' }
+	assert.True(t, ContainsOnly("ae\u0301", vowels))
+	assert.False(t, ContainsOnly("ao", vowels))
+
+	basicLatin := func(r rune) bool { return r < utf8.RuneSelf && unicode.IsLetter(r) }
+	assert.False(t, ContainsOnly("q\u0307", basicLatin))
+	assert.False(t, ContainsOnly("h\u0338e\u0337", basicLatin))
+	assert.False(t, ContainsOnly("h\u0338e\u0337", func(r rune) bool { return strings.ContainsRune("he", r) }))
+}
+
+func TestContainsOnlyLettersOr(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, ContainsOnlyLettersOr("", nil))
+	assert.True(t, ContainsOnlyLettersOr("\u0928\u092e\u0938\u094d\u0924\u0947", nil))
+	assert.True(t, ContainsOnlyLettersOr("q\u0307", nil))
+	assert.True(t, ContainsOnlyLettersOr("Jose\u0301 Maria", unicode.IsSpace))
+	assert.False(t, ContainsOnlyLettersOr("Jose Maria", nil))
+	assert.False(t, ContainsOnlyLettersOr("\u0301e", nil))
+	assert.False(t, ContainsOnlyLettersOr(" \u0301", unicode.IsSpace))
+	assert.False(t, ContainsOnlyLettersOr("ab\u200bc", nil))
+	assert.False(t, ContainsOnlyLettersOr("ab\u3164", nil))
+}
+
+func TestUnicodeRules(t *testing.T) {
+	t.Parallel()
+
+	type Account struct {
+		Username string `json:"username" sanitize:"nfc" valid:"required|printable|singlescript|noconfusable"`
+		Bio      string `json:"bio" valid:"printable"`
+	}
+
+	bag, err := ValidateStruct(Account{Username: "Zoë", Bio: "Hi!\nI'm Zoë."})
+	assert.Nil(t, err)
+	assert.Empty(t, bag.Errors())
+
+	bag, err = ValidateStruct(Account{Username: "p\u0430yp\u0430l", Bio: "\u202egnp.exe"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"username:singlescript", "username:noconfusable", "bio:printable"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "Username must be written in a single script", bag.FirstErrorMessage())
+	assert.Equal(t, "Bio must not contain invisible or control characters", bag.Errors()[2].Message())
+
+	bag, err = ValidateStruct(Account{Username: "ｚｏｅ"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"username:noconfusable"}, embeddedErrorPaths(bag))
+	assert.Equal(t, "Username must not contain look-alike characters", bag.FirstErrorMessage())
+}
@@ -0,0 +1,160 @@
+package validate
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// UnwrapFunc returns the value held by a wrapper type such as sql.NullString. valid is false if the wrapper holds
+// no value (a NULL column), in which case the field is validated like a nil pointer: required fails and the other
+// validators are skipped.
+type UnwrapFunc func(v interface{}) (value interface{}, valid bool)
+
+type unwrapperRegistry struct {
+	sync.RWMutex
+	unwrappers map[reflect.Type]UnwrapFunc
+}
+
+var unwrappers = newUnwrapperRegistry()
+
+var (
+	valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+	// nullValue is what a NULL wrapper unwraps to: a nil interface, so it goes through the same path as nil pointers
+	nullValue = reflect.Zero(reflect.TypeOf((*interface{})(nil)).Elem())
+)
+
+func newUnwrapperRegistry() *unwrapperRegistry {
+	r := &unwrapperRegistry{unwrappers: make(map[reflect.Type]UnwrapFunc)}
+
+	r.add(sql.NullString{}, func(v interface{}) (interface{}, bool) {
+		n := v.(sql.NullString)
+		return n.String, n.Valid
+	})
+	r.add(sql.NullInt64{}, func(v interface{}) (interface{}, bool) {
+		n := v.(sql.NullInt64)
+		return n.Int64, n.Valid
+	})
+	r.add(sql.NullInt32{}, func(v interface{}) (interface{}, bool) {
+		n := v.(sql.NullInt32)
+		return n.Int32, n.Valid
+	})
+	r.add(sql.NullInt16{}, func(v interface{}) (interface{}, bool) {
+		n := v.(sql.NullInt16)
+		return n.Int16, n.Valid
+	})
+	r.add(sql.NullByte{}, func(v interface{}) (interface{}, bool) {
+		n := v.(sql.NullByte)
+		return n.Byte, n.Valid
+	})
+	r.add(sql.NullFloat64{}, func(v interface{}) (interface{}, bool) {
+		n := v.(sql.NullFloat64)
+		return n.Float64, n.Valid
+	})
+	r.add(sql.NullBool{}, func(v interface{}) (interface{}, bool) {
+		n := v.(sql.NullBool)
+		return n.Bool, n.Valid
+	})
+	r.add(sql.NullTime{}, func(v interface{}) (interface{}, bool) {
+		n := v.(sql.NullTime)
+		return n.Time, n.Valid
+	})
+
+	return r
+}
+
+func (r *unwrapperRegistry) add(sample interface{}, fn UnwrapFunc) {
+	ty := reflect.TypeOf(sample)
+	if ty.Kind() == reflect.Ptr {
+		ty = ty.Elem()
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	r.unwrappers[ty] = fn
+}
+
+func (r *unwrapperRegistry) get(ty reflect.Type) (UnwrapFunc, bool) {
+	r.RLock()
+	defer r.RUnlock()
+	fn, ok := r.unwrappers[ty]
+	return fn, ok
+}
+
+// RegisterUnwrapper registers the unwrap function for the type of sample, so fields of that type are validated
+// using the value they wrap. Registered functions take precedence over driver.Valuer, and are the only way to
+// unwrap structs whose fields have valid or sanitize tags.
+func RegisterUnwrapper(sample interface{}, fn UnwrapFunc) {
+	unwrappers.add(sample, fn)
+}
+
+// isWrapperType is true if values of ty are unwrapped before validation: the registered wrappers, and the
+// driver.Valuer types, such as uuid.NullUUID or decimal.NullDecimal, whose Value method gives the value to validate.
+// Struct valuers whose fields have valid or sanitize tags, such as JSON columns, are validated field by field like
+// other structs, and their Value method, which serializes them, isn't called.
+func isWrapperType(ty reflect.Type) bool {
+	if _, ok := unwrappers.get(ty); ok {
+		return true
+	}
+	if ty.Kind() == reflect.Struct && hasFieldTags(ty) {
+		return false
+	}
+	return ty.Implements(valuerType) || reflect.PtrTo(ty).Implements(valuerType)
+}
+
+// hasFieldTags is true if a field of the struct type ty, or of the structs it embeds, has a valid or sanitize tag
+func hasFieldTags(ty reflect.Type) bool {
+	for i := 0; i < ty.NumField(); i++ {
+		field := ty.Field(i)
+		if _, ok := field.Tag.Lookup(tagName); ok {
+			return true
+		}
+		if _, ok := field.Tag.Lookup(sanitizeTagName); ok {
+			return true
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && hasFieldTags(field.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// unwrapValue returns the value wrapped by v, nullValue if v holds no value, or v itself if its type isn't a
+// wrapper. Pointers are never unwrapped here; validateField unwraps their element.
+func unwrapValue(v reflect.Value) (reflect.Value, error) {
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface || !v.CanInterface() {
+		return v, nil
+	}
+
+	if fn, ok := unwrappers.get(v.Type()); ok {
+		value, valid := fn(v.Interface())
+		if !valid || value == nil {
+			return nullValue, nil
+		}
+		return reflect.ValueOf(value), nil
+	}
+
+	var valuer driver.Valuer
+	if v.Kind() == reflect.Struct && hasFieldTags(v.Type()) {
+		return v, nil
+	} else if v.Type().Implements(valuerType) {
+		valuer = v.Interface().(driver.Valuer)
+	} else if v.CanAddr() && v.Addr().Type().Implements(valuerType) {
+		valuer = v.Addr().Interface().(driver.Valuer)
+	} else {
+		return v, nil
+	}
+
+	value, err := valuer.Value()
+	if err != nil {
+		return v, fmt.Errorf("%s.Value() failed: %s", v.Type().Name(), err.Error())
+	}
+	if value == nil {
+		return nullValue, nil
+	}
+
+	return reflect.ValueOf(value), nil
+}
@@ -0,0 +1,201 @@
+package validate
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// status is a custom column type stored as its upper case code
+type status string
+
+func (s status) Value() (driver.Value, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return strings.ToUpper(string(s)), nil
+}
+
+// brokenValuer always fails to produce a value
+type brokenValuer string
+
+func (brokenValuer) Value() (driver.Value, error) {
+	return nil, errors.New("no connection")
+}
+
+// settings is a struct stored as a JSON column
+type settings struct {
+	Theme  string `json:"theme" valid:"required"`
+	Locale string `json:"locale" valid:"alpha"`
+}
+
+func (s settings) Value() (driver.Value, error) {
+	panic("settings are serialized when saved, not when validated")
+}
+
+// nullCode is a nullable column type in the style of uuid.NullUUID: a struct without tags whose Value is the value
+// to validate
+type nullCode struct {
+	Code  string
+	Valid bool
+}
+
+func (n nullCode) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Code, nil
+}
+
+// optional is an application wrapper type that doesn't implement driver.Valuer
+type optional struct {
+	Set   bool
+	Value string
+}
+
+func TestNullTypesAreUnwrapped(t *testing.T) {
+	t.Parallel()
+
+	type Customer struct {
+		Name    sql.NullString  `json:"name" valid:"required|between(2,10)"`
+		Email   sql.NullString  `json:"email" valid:"email"`
+		Age     sql.NullInt64   `json:"age" valid:"required"`
+		Score   *sql.NullInt32  `json:"score" valid:"between(1,5)"`
+		Balance sql.NullFloat64 `json:"balance" valid:"required"`
+	}
+
+	bag, err := ValidateStruct(Customer{
+		Name:    sql.NullString{String: "a", Valid: true},
+		Email:   sql.NullString{String: "invalid", Valid: false},
+		Age:     sql.NullInt64{Int64: 0, Valid: true},
+		Score:   &sql.NullInt32{Int32: 9, Valid: true},
+		Balance: sql.NullFloat64{Float64: 10, Valid: false},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"name:between", "age:required", "score:between", "balance:required"}, embeddedErrorPaths(bag))
+
+	bag, err = ValidateStruct(Customer{
+		Name:    sql.NullString{String: "Alice", Valid: true},
+		Age:     sql.NullInt64{Int64: 30, Valid: true},
+		Balance: sql.NullFloat64{Float64: 10, Valid: true},
+	})
+	assert.Nil(t, err)
+	assert.False(t, bag.HasErrors(), bag.String())
+}
+
+func TestNullValueCountsAsEmpty(t *testing.T) {
+	t.Parallel()
+
+	type Customer struct {
+		Name sql.NullString `json:"name" valid:"required"`
+	}
+
+	bag, err := ValidateStruct(Customer{Name: sql.NullString{String: "Alice", Valid: false}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"name:required"}, embeddedErrorPaths(bag))
+	assert.Nil(t, bag.Errors()[0].Value)
+}
+
+func TestValuerTypesAreUnwrapped(t *testing.T) {
+	t.Parallel()
+
+	type Order struct {
+		Status   status   `json:"status" valid:"required|uppercase"`
+		Statuses []status `json:"statuses" valid:"uppercase"`
+	}
+
+	bag, err := ValidateStruct(Order{Status: "open", Statuses: []status{"open", ""}})
+	assert.Nil(t, err)
+	assert.False(t, bag.HasErrors(), bag.String())
+
+	bag, err = ValidateStruct(Order{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"status:required"}, embeddedErrorPaths(bag))
+}
+
+func TestStructValuersAreValidatedByField(t *testing.T) {
+	t.Parallel()
+
+	type Account struct {
+		Settings  settings   `json:"settings"`
+		Previous  *settings  `json:"previous"`
+		Favorites []settings `json:"favorites"`
+	}
+
+	bag, err := ValidateStruct(Account{
+		Settings:  settings{Theme: "dark", Locale: "FR"},
+		Previous:  &settings{Locale: "fr1"},
+		Favorites: []settings{{Theme: "light"}, {}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"previous.theme:required", "previous.locale:alpha", "favorites[1].theme:required"},
+		embeddedErrorPaths(bag))
+}
+
+func TestUntaggedStructValuersAreUnwrapped(t *testing.T) {
+	t.Parallel()
+
+	type Coupon struct {
+		Code     nullCode      `json:"code" valid:"required|uppercase"`
+		Previous *nullCode     `json:"previous" valid:"uppercase"`
+		Uses     sql.NullInt16 `json:"uses" valid:"required|between(1,5)"`
+		Tier     sql.NullByte  `json:"tier" valid:"required"`
+	}
+
+	bag, err := ValidateStruct(Coupon{
+		Code:     nullCode{Code: "SPRING", Valid: true},
+		Previous: &nullCode{},
+		Uses:     sql.NullInt16{Int16: 3, Valid: true},
+		Tier:     sql.NullByte{Byte: 1, Valid: true},
+	})
+	assert.Nil(t, err)
+	assert.False(t, bag.HasErrors(), bag.String())
+
+	bag, err = ValidateStruct(Coupon{
+		Code:     nullCode{Code: "SPRING"},
+		Previous: &nullCode{Code: "winter", Valid: true},
+		Uses:     sql.NullInt16{Int16: 9, Valid: true},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"code:required", "previous:uppercase", "uses:between", "tier:required"}, embeddedErrorPaths(bag))
+}
+
+func TestValuerErrorIsInternalError(t *testing.T) {
+	t.Parallel()
+
+	type Broken struct {
+		Value brokenValuer `valid:"required"`
+	}
+
+	_, err := ValidateStruct(Broken{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "no connection")
+}
+
+func TestRegisterUnwrapper(t *testing.T) {
+	t.Parallel()
+
+	RegisterUnwrapper(optional{}, func(v interface{}) (interface{}, bool) {
+		o := v.(optional)
+		return o.Value, o.Set
+	})
+
+	type Profile struct {
+		Nickname optional   `json:"nickname" valid:"required|alpha"`
+		Aliases  []optional `json:"aliases" valid:"alpha"`
+	}
+
+	bag, err := ValidateStruct(Profile{
+		Nickname: optional{Set: true, Value: "bob42"},
+		Aliases:  []optional{{Set: true, Value: "bobby"}, {Set: true, Value: "b0b"}, {}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"nickname:alpha", "aliases[1]:alpha"}, embeddedErrorPaths(bag))
+
+	bag, err = ValidateStruct(Profile{Nickname: optional{Value: "bob"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"nickname:required"}, embeddedErrorPaths(bag))
+}
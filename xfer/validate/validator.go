@@ -1,9 +1,11 @@
 package validate
 
 import (
+	"context"
 	"fmt"
 	"github.com/ansel1/merry"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -96,13 +98,94 @@ func clearCustomValidation() {
 // result will contain validation errors or be empty if there are none (HasErrors() returns false)
 // error is set only if there is an internal Validator error (as opposed to a failed validation)
 func ValidateStruct(s interface{}) (*ErrorBag, error) {
-	return doValidateStruct(s, NewErrorBag(), nil)
+	return ValidateStructContext(context.Background(), s)
+}
+
+// ValidateStructContext is ValidateStruct with messages rendered in the locale carried by ctx (see WithLocale).
+// Slow validators stop when ctx is done.
+func ValidateStructContext(ctx context.Context, s interface{}) (*ErrorBag, error) {
+	bag, err := doValidateStruct(s, newLocalizedErrorBag(LocaleFromContext(ctx)), nil, ``)
+	return runSlowChecks(ctx, bag, err)
 }
 
 // CustomValidateStruct validates the interfaces using custom validations (registered with AddCustomValidation)
 // The default validation (defined with valid tags) can be used with "valid"
 func CustomValidateStruct(s interface{}, customValidations ...string) (*ErrorBag, error) {
-	bag := NewErrorBag()
+	return CustomValidateStructContext(context.Background(), s, customValidations...)
+}
+
+// CustomValidateStructContext is CustomValidateStruct with messages rendered in the locale carried by ctx
+func CustomValidateStructContext(ctx context.Context, s interface{}, customValidations ...string) (*ErrorBag, error) {
+	bag, err := doCustomValidateStruct(s, newLocalizedErrorBag(LocaleFromContext(ctx)), customValidations)
+	return runSlowChecks(ctx, bag, err)
+}
+
+// varFieldName is the field name used in ValidateVar and ValidateSlice messages, unless the tag sets one with name=
+const varFieldName = "Value"
+
+// ValidateVar validates a single value, such as a query param, using the same tag syntax and validators as
+// ValidateStruct: ValidateVar(email, "required|email|name=Email"). Errors have an empty path.
+// error is set only if there is an internal Validator error or the tag is invalid
+func ValidateVar(value interface{}, tag string) (*ErrorBag, error) {
+	return ValidateVarContext(context.Background(), value, tag)
+}
+
+// ValidateVarContext is ValidateVar with messages rendered in the locale carried by ctx (see WithLocale)
+func ValidateVarContext(ctx context.Context, value interface{}, tag string) (*ErrorBag, error) {
+	bag, err := doValidateVar(value, tag, newLocalizedErrorBag(LocaleFromContext(ctx)))
+	return runSlowChecks(ctx, bag, err)
+}
+
+// ValidateSlice validates each element of a slice or array with tag. Error paths are the element indexes ([0], [1]
+// etc.), and an empty slice has no errors.
+func ValidateSlice(values interface{}, tag string) (*ErrorBag, error) {
+	return ValidateSliceContext(context.Background(), values, tag)
+}
+
+// ValidateSliceContext is ValidateSlice with messages rendered in the locale carried by ctx (see WithLocale)
+func ValidateSliceContext(ctx context.Context, values interface{}, tag string) (*ErrorBag, error) {
+	bag, err := doValidateSlice(values, tag, newLocalizedErrorBag(LocaleFromContext(ctx)))
+	return runSlowChecks(ctx, bag, err)
+}
+
+func doValidateVar(value interface{}, tag string, bag *ErrorBag) (*ErrorBag, error) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		// validate untyped nil like a nil pointer, so required fails
+		v = nullValue
+	}
+
+	t, customFieldTags := varField(v, tag)
+	return bag, validateField(v, t, reflect.Value{}, bag, customFieldTags, ``)
+}
+
+func doValidateSlice(values interface{}, tag string, bag *ErrorBag) (*ErrorBag, error) {
+	v := reflect.ValueOf(values)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return bag, fmt.Errorf("ValidateSlice only accepts slices and arrays; got %s", v.Kind())
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		t, customFieldTags := varField(elem, tag)
+		if err := validateField(elem, t, reflect.Value{}, bag, customFieldTags, indexPath(``, i)); err != nil {
+			return bag, err
+		}
+	}
+
+	return bag, nil
+}
+
+// varField describes a value that isn't a struct field as one, with its tag passed as a custom field tag so it
+// doesn't need to be quoted
+func varField(v reflect.Value, tag string) (reflect.StructField, map[string]string) {
+	return reflect.StructField{Name: varFieldName, Type: v.Type()}, map[string]string{varFieldName: tag}
+}
+
+func doCustomValidateStruct(s interface{}, bag *ErrorBag, customValidations []string) (*ErrorBag, error) {
 	for _, customValidation := range customValidations {
 		var validations map[string]string
 		if customValidation != tagName {
@@ -112,7 +195,7 @@ func CustomValidateStruct(s interface{}, customValidations ...string) (*ErrorBag
 			}
 			validations = v
 		}
-		_, err := doValidateStruct(s, bag, validations)
+		_, err := doValidateStruct(s, bag, validations, ``)
 		if err != nil {
 			return bag, err
 		}
@@ -120,8 +203,8 @@ func CustomValidateStruct(s interface{}, customValidations ...string) (*ErrorBag
 	return bag, nil
 }
 
-// to allow recursive calling with the same error bag
-func doValidateStruct(s interface{}, bag *ErrorBag, customFieldTags map[string]string) (*ErrorBag, error) {
+// to allow recursive calling with the same error bag. path is the path of s from the root struct, empty for the root.
+func doValidateStruct(s interface{}, bag *ErrorBag, customFieldTags map[string]string, path string) (*ErrorBag, error) {
 
 	var internalError error
 
@@ -140,20 +223,104 @@ func doValidateStruct(s interface{}, bag *ErrorBag, customFieldTags map[string]s
 		return bag, fmt.Errorf("doValidateStruct only accepts structs; got %s", obj.Kind())
 	}
 
+	internalError = validateStructFields(obj, bag, customFieldTags, path, nil)
+
+	return bag, internalError
+}
+
+// validateStructFields validates the fields of obj, following Go embedding rules for embedded structs: their fields
+// are promoted (validated as if they were declared in obj) unless a field with the same name is declared at a
+// shallower depth, listed in shadowed.
+func validateStructFields(obj reflect.Value, bag *ErrorBag, customFieldTags map[string]string, path string, shadowed map[string]bool) error {
+
+	// fields declared at this depth shadow promoted fields with the same name
+	declared := make(map[string]bool, len(shadowed)+obj.NumField())
+	for name := range shadowed {
+		declared[name] = true
+	}
+	for i := 0; i < obj.NumField(); i++ {
+		if typeField := obj.Type().Field(i); !typeField.Anonymous {
+			declared[typeField.Name] = true
+		}
+	}
+
 	for i := 0; i < obj.NumField(); i++ {
 		valueField := obj.Field(i)
 		typeField := obj.Type().Field(i)
+		if shadowed[typeField.Name] {
+			continue // overridden by a field of the embedding struct
+		}
+
+		if isPromotingEmbeddedStruct(typeField) {
+			if err := validateEmbeddedStruct(valueField, typeField, obj, bag, customFieldTags, path, declared); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if typeField.PkgPath != "" {
 			continue // Private field
 		}
 
-		internalError = validateField(valueField, typeField, obj, bag, customFieldTags)
-		if internalError != nil {
-			break
+		if err := validateField(valueField, typeField, obj, bag, customFieldTags, joinPath(path, fieldKey(typeField))); err != nil {
+			return err
 		}
 	}
 
-	return bag, internalError
+	return nil
+}
+
+// isPromotingEmbeddedStruct is true for embedded structs (or pointers to structs) whose fields are promoted. Like
+// encoding/json, an embedded struct with a form or json name is treated as a named field instead.
+func isPromotingEmbeddedStruct(t reflect.StructField) bool {
+	if !t.Anonymous {
+		return false
+	}
+
+	ty := t.Type
+	if ty.Kind() == reflect.Ptr {
+		ty = ty.Elem()
+	}
+	if ty.Kind() != reflect.Struct {
+		return false
+	}
+
+	return fieldKey(t) == t.Name
+}
+
+// validateEmbeddedStruct validates the valid tag of an embedded struct, then its promoted fields. An embedded
+// struct tagged with valid:"-" is skipped altogether.
+func validateEmbeddedStruct(v reflect.Value, t reflect.StructField, o reflect.Value, bag *ErrorBag, customFieldTags map[string]string, path string, shadowed map[string]bool) error {
+
+	var tag string
+	if customFieldTags == nil {
+		tag = t.Tag.Get(tagName)
+	} else {
+		tag = customFieldTags[t.Name]
+	}
+	if tag == "-" {
+		return nil
+	}
+
+	// the embedded value itself can't be read when its type is unexported, but its exported fields can
+	if t.PkgPath == "" {
+		fieldValidators, err := getFieldValidators(v, t, o, customFieldTags)
+		if err != nil {
+			return err
+		}
+		if err := validateComplexType(v, t, fieldValidators, bag, joinPath(path, t.Name)); err != nil {
+			return err
+		}
+	}
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	return validateStructFields(v, bag, customFieldTags, path, shadowed)
 }
 
 // parse struct field tags and return a slice of FieldValidators
@@ -175,22 +342,23 @@ func getFieldValidators(v reflect.Value, t reflect.StructField, o reflect.Value,
 
 	rawKeys := strings.Split(tag, validatorSeparator)
 
-	// set default field name using titlecase equivalent
-	fieldName := strings.Title(t.Name)
-
-	// process settings, only supported setting at the moment is field name
-	rawKeys, name, err := extractFieldName(rawKeys)
-	if len(name) > 0 {
-		fieldName = name
-	} else if err != nil {
+	// process settings
+	rawKeys, settings, err := extractSettings(rawKeys)
+	if err != nil {
 		return nil, err
 	}
 
+	// the name setting takes precedence over the label provider
+	label := newFieldLabel(o, t, settings.name)
+	fieldName := label.in(currentMessagesLocale())
+	redact := settings.redact || redactingValidators(rawKeys)
+
 	// handle validator directives
 	for _, key := range rawKeys {
 		if key == "-" || key == "" {
 			continue
 		}
+		directive := key
 
 		if len(key) == 0 {
 			if customFieldTags != nil {
@@ -203,6 +371,9 @@ func getFieldValidators(v reflect.Value, t reflect.StructField, o reflect.Value,
 		validator := FieldValidator{
 			FieldName:  fieldName,
 			FieldValue: v.Interface(),
+			IsRedacted: redact,
+			parent:     o,
+			label:      label,
 		}
 
 		// after each operation for negation,message, and parameters we reset the key to exclude
@@ -232,8 +403,17 @@ func getFieldValidators(v reflect.Value, t reflect.StructField, o reflect.Value,
 			return nil, fmt.Errorf("Invalid validation key for field %s: %s", fieldName, key)
 		}
 
+		validator.ValidatorKey = key
 		validator.Validator = *v
 
+		checkKey := messageCheckKey{field: t.Name, directive: directive}
+		if o.IsValid() {
+			checkKey.parent = o.Type()
+		}
+		if validator.otherFields, err = cachedMessageCheck(checkKey, validator); err != nil {
+			return nil, err
+		}
+
 		fieldValidators = append(fieldValidators, validator)
 	}
 
@@ -294,24 +474,68 @@ func tmpLookup(tag string, key string) (value string, ok bool) {
 	return "", false
 }
 
-// extract the field name from the tag, return the tag keys with the name removed, plus the field name and error
-func extractFieldName(rawKeys []string) ([]string, string, error) {
-	for idx, key := range rawKeys {
+// fieldSettings holds the settings (xxxx=yyy) given in a field's tag
+type fieldSettings struct {
+	name   string
+	redact bool
+}
+
+// extract the settings from the tag, return the tag keys with the settings removed, plus the settings and error.
+// Supported settings are name (the field's display name) and redact (true to keep the rejected value out of errors)
+func extractSettings(rawKeys []string) ([]string, fieldSettings, error) {
+	var settings fieldSettings
+	keys := make([]string, 0, len(rawKeys))
+
+	for _, key := range rawKeys {
 
+		// an = inside the params of a validator, e.g. semverrange(>=1.2.0), doesn't make a setting
 		settingTokenIndex := strings.Index(key, settingsToken)
-		if settingTokenIndex > 0 {
-			rawKeys = append(rawKeys[:idx], rawKeys[idx+1:]...)
-			settingName := key[:settingTokenIndex]
-			switch settingName {
-			case "name":
-				return rawKeys, key[settingTokenIndex+1:], nil
-			default:
-				return rawKeys, ``, fmt.Errorf("%s is not a valid validation option", settingName)
+		paramsOpenIndex := strings.Index(key, paramOpenToken)
+		if settingTokenIndex <= 0 || (paramsOpenIndex >= 0 && paramsOpenIndex < settingTokenIndex) {
+			keys = append(keys, key)
+			continue
+		}
+
+		settingName := key[:settingTokenIndex]
+		settingValue := key[settingTokenIndex+1:]
+		switch settingName {
+		case "name":
+			settings.name = settingValue
+		case "redact":
+			redact, err := strconv.ParseBool(settingValue)
+			if err != nil {
+				return keys, settings, fmt.Errorf("%s is not a valid value for the redact option", settingValue)
 			}
+			settings.redact = redact
+		default:
+			return keys, settings, fmt.Errorf("%s is not a valid validation option", settingName)
 		}
 	}
 
-	return rawKeys, ``, nil
+	return keys, settings, nil
+}
+
+// isRedactedField is true if the field's tag has the redact=true setting or a validator with Redact
+func isRedactedField(t reflect.StructField) bool {
+	keys, settings, err := extractSettings(strings.Split(t.Tag.Get(tagName), validatorSeparator))
+	return err == nil && (settings.redact || redactingValidators(keys))
+}
+
+// redactingValidators is true if one of the validator directives uses a validator with Redact
+func redactingValidators(keys []string) bool {
+	for _, key := range keys {
+		key = strings.TrimPrefix(key, "!")
+		if i := strings.Index(key, customMessageToken); i >= 0 {
+			key = key[:i]
+		}
+		if i := strings.Index(key, paramOpenToken); i >= 0 {
+			key = key[:i]
+		}
+		if v, ok := GetValidator(key); ok && v.Redact {
+			return true
+		}
+	}
+	return false
 }
 
 func extractMessage(key string, fieldName string, negated bool) (string, *MessageSet, error) {
@@ -383,8 +607,8 @@ func extractParams(rawParamStr string) []interface{} {
 	param := ``
 	for _, v := range re {
 
-		// skip escaped paramSeparator instances
-		if string(v[len(v)-1]) == `\` && string(v[len(v)-2]) != `\\` {
+		// skip escaped paramSeparator instances. Empty pieces are empty params, e.g. dimensions(,,800,600)
+		if len(v) > 1 && string(v[len(v)-1]) == `\` && string(v[len(v)-2]) != `\\` {
 			param += v + paramSeparator
 			continue
 		}
@@ -398,17 +622,28 @@ func extractParams(rawParamStr string) []interface{} {
 	return params
 }
 
-func validateField(v reflect.Value, t reflect.StructField, o reflect.Value, validationErrs *ErrorBag, customFieldTags map[string]string) error {
+func validateField(v reflect.Value, t reflect.StructField, o reflect.Value, validationErrs *ErrorBag, customFieldTags map[string]string, path string) error {
 
 	if !v.IsValid() {
 		return nil
 	}
 
+	// sql.Null* and driver.Valuer fields are validated using the value they wrap
+	v, err := unwrapValue(v)
+	if err != nil {
+		return fmt.Errorf("Error validating %s: %s", t.Name, err.Error())
+	}
+
 	fieldValidators, err := getFieldValidators(v, t, o, customFieldTags)
 	if err != nil {
 		return err
 	}
 
+	// uploaded files are validated as a whole by the file rules (maxsize, mimes...), nil or not
+	if isFileType(v.Type()) {
+		return validateBasicType(v, t, fieldValidators, validationErrs, path)
+	}
+
 	// todo add time.Time
 	switch v.Kind() {
 	case reflect.Bool,
@@ -417,34 +652,34 @@ func validateField(v reflect.Value, t reflect.StructField, o reflect.Value, vali
 		reflect.Float32, reflect.Float64,
 		reflect.String:
 
-		err = validateBasicType(v, t, fieldValidators, validationErrs)
+		err = validateBasicType(v, t, fieldValidators, validationErrs, path)
 		if err != nil {
 			return err
 		}
 
 	case reflect.Map:
-		if err := validateComplexType(v, t, fieldValidators, validationErrs); err != nil {
+		if err := validateComplexType(v, t, fieldValidators, validationErrs, path); err != nil {
 			return err
 		}
-		if err := validateMap(v, validationErrs, customFieldTags); err != nil {
+		if err := validateMap(v, validationErrs, customFieldTags, path); err != nil {
 			return err
 		}
 	case reflect.Slice:
-		if err := validateComplexType(v, t, fieldValidators, validationErrs); err != nil {
+		if err := validateComplexType(v, t, fieldValidators, validationErrs, path); err != nil {
 			return err
 		}
-		if err := validateArrayOrSlice(v, t, o, validationErrs, customFieldTags); err != nil {
+		if err := validateArrayOrSlice(v, t, o, validationErrs, customFieldTags, path); err != nil {
 			return err
 		}
 	case reflect.Array:
-		if err := validateComplexType(v, t, fieldValidators, validationErrs); err != nil {
+		if err := validateComplexType(v, t, fieldValidators, validationErrs, path); err != nil {
 			return err
 		}
-		if err := validateArrayOrSlice(v, t, o, validationErrs, customFieldTags); err != nil {
+		if err := validateArrayOrSlice(v, t, o, validationErrs, customFieldTags, path); err != nil {
 			return err
 		}
 	case reflect.Interface:
-		if err := validateComplexType(v, t, fieldValidators, validationErrs); err != nil {
+		if err := validateComplexType(v, t, fieldValidators, validationErrs, path); err != nil {
 			return err
 		}
 		// If the value is an interface then encode its element
@@ -452,23 +687,23 @@ func validateField(v reflect.Value, t reflect.StructField, o reflect.Value, vali
 			return nil
 		}
 
-		if _, err := doValidateStruct(v.Interface(), validationErrs, customFieldTags); err != nil {
+		if _, err := doValidateStruct(v.Interface(), validationErrs, customFieldTags, path); err != nil {
 			return err
 		}
 	case reflect.Ptr:
-		if err := validateComplexType(v, t, fieldValidators, validationErrs); err != nil {
+		if err := validateComplexType(v, t, fieldValidators, validationErrs, path); err != nil {
 			return err
 		}
 		// If the value is a pointer then check its element
 		if v.IsNil() {
 			return nil
 		}
-		return validateField(v.Elem(), t, o, validationErrs, customFieldTags)
+		return validateField(v.Elem(), t, o, validationErrs, customFieldTags, path)
 	case reflect.Struct:
-		if err := validateComplexType(v, t, fieldValidators, validationErrs); err != nil {
+		if err := validateComplexType(v, t, fieldValidators, validationErrs, path); err != nil {
 			return err
 		}
-		if _, err = doValidateStruct(v.Interface(), validationErrs, customFieldTags); err != nil {
+		if _, err = doValidateStruct(v.Interface(), validationErrs, customFieldTags, path); err != nil {
 			return err
 		}
 	default:
@@ -478,50 +713,67 @@ func validateField(v reflect.Value, t reflect.StructField, o reflect.Value, vali
 	return nil
 }
 
-func errorKey(t reflect.StructField, v FieldValidator) string {
+// errorKey returns the key of a field's errors in the ErrorBag, which is its key in error paths and HTMLFields
+func errorKey(t reflect.StructField) string {
+	return fieldKey(t)
+}
 
-	// usually we don't want to use the field names as error bag keys. Try form and json first
-	var errorBagKey = t.Tag.Get(`form`)
-	if errorBagKey != `` {
-		return errorBagKey
+// fieldKey returns the key for a field in error paths: the form tag, then the json tag, then the field name
+func fieldKey(t reflect.StructField) string {
+	for _, tag := range []string{`form`, `json`} {
+		key := strings.Split(t.Tag.Get(tag), ",")[0]
+		if key != `` && key != `-` {
+			return key
+		}
 	}
+	return t.Name
+}
 
-	errorBagKey = t.Tag.Get(`json`)
-	if errorBagKey != `` {
-		return errorBagKey
+func joinPath(path, key string) string {
+	if len(path) == 0 {
+		return key
 	}
+	return path + "." + key
+}
 
-	return v.Validator.Key
+func indexPath(path string, index interface{}) string {
+	return fmt.Sprintf("%s[%v]", path, index)
 }
 
-func validateBasicType(v reflect.Value, t reflect.StructField, fieldValidators []FieldValidator, validationErrs *ErrorBag) error {
+func validateBasicType(v reflect.Value, t reflect.StructField, fieldValidators []FieldValidator, validationErrs *ErrorBag, path string) error {
 
 	for _, validator := range fieldValidators {
+		if validator.Validator.IsSlow {
+			validationErrs.deferCheck(v, t, validator, path)
+			continue
+		}
 
-		valid, err := validator.Validator.Validate(v, validator.ValidatorParams)
+		valid, err := validator.check(context.Background(), v)
 		if err != nil {
 			return fmt.Errorf("Error validating %s: %s", t.Name, err.Error())
 		}
 
 		if !valid {
-			validationErrs.Add(errorKey(t, validator), validator.Message(), validator.FieldName)
+			validationErrs.addError(validator.newError(errorKey(t), path, validationErrs.locale))
 		}
 	}
 
 	return nil
 }
 
-func validateComplexType(v reflect.Value, t reflect.StructField, fieldValidators []FieldValidator, validationErrs *ErrorBag) error {
+func validateComplexType(v reflect.Value, t reflect.StructField, fieldValidators []FieldValidator, validationErrs *ErrorBag, path string) error {
 
 	for _, validator := range fieldValidators {
-		if validator.CanValidateComplexTypes() {
-			valid, err := validator.Validator.Validate(v, validator.ValidatorParams)
+		if validator.CanValidateComplexTypes() && validator.Validator.IsSlow {
+			validationErrs.deferCheck(v, t, validator, path)
+		} else if validator.CanValidateComplexTypes() {
+			valid, err := validator.check(context.Background(), v)
 			if err != nil {
 				return fmt.Errorf("Error validating %s: %s", t.Name, err.Error())
 			}
 
 			if !valid {
-				validationErrs.Add(errorKey(t, validator), validator.Message(), validator.FieldName)
+				validationErrs.addError(validator.newError(errorKey(t), path, validationErrs.locale))
 			}
 		}
 	}
@@ -530,7 +782,7 @@ func validateComplexType(v reflect.Value, t reflect.StructField, fieldValidators
 }
 
 // fixme - currently only works for maps where values are structs. modify to also handle basic types
-func validateMap(v reflect.Value, validationErrs *ErrorBag, customFieldTags map[string]string) error {
+func validateMap(v reflect.Value, validationErrs *ErrorBag, customFieldTags map[string]string, path string) error {
 
 	// check len
 
@@ -538,11 +790,14 @@ func validateMap(v reflect.Value, validationErrs *ErrorBag, customFieldTags map[
 		return fmt.Errorf("%s is not a supported validation type", v.Kind().String())
 	}
 
-	// check value type
+	// check value type. Keys are sorted so errors are added in the same order every time
 	var sv = v.MapKeys()
+	sort.Slice(sv, func(i, j int) bool {
+		return sv[i].String() < sv[j].String()
+	})
 	for _, k := range sv {
 		if v.MapIndex(k).Kind() == reflect.Struct {
-			_, err := doValidateStruct(v.MapIndex(k).Interface(), validationErrs, customFieldTags)
+			_, err := doValidateStruct(v.MapIndex(k).Interface(), validationErrs, customFieldTags, indexPath(path, k.Interface()))
 			if err != nil {
 				return err
 			}
@@ -552,16 +807,16 @@ func validateMap(v reflect.Value, validationErrs *ErrorBag, customFieldTags map[
 	return nil
 }
 
-func validateArrayOrSlice(v reflect.Value, t reflect.StructField, o reflect.Value, validationErrs *ErrorBag, customFieldTags map[string]string) error {
+func validateArrayOrSlice(v reflect.Value, t reflect.StructField, o reflect.Value, validationErrs *ErrorBag, customFieldTags map[string]string, path string) error {
 	for i := 0; i < v.Len(); i++ {
 		var err error
-		if v.Index(i).Kind() != reflect.Struct {
-			err = validateField(v.Index(i), t, o, validationErrs, customFieldTags)
+		if v.Index(i).Kind() != reflect.Struct || isWrapperType(v.Index(i).Type()) || isFileType(v.Index(i).Type()) {
+			err = validateField(v.Index(i), t, o, validationErrs, customFieldTags, indexPath(path, i))
 			if err != nil {
 				return err
 			}
 		} else {
-			_, err = doValidateStruct(v.Index(i).Interface(), validationErrs, customFieldTags)
+			_, err = doValidateStruct(v.Index(i).Interface(), validationErrs, customFieldTags, indexPath(path, i))
 			if err != nil {
 				return err
 			}
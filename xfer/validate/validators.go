@@ -38,12 +38,14 @@ import (
 	"unicode/utf8"
 )
 
+// IsEmail check if the string is a bare RFC 5322 address at an internet domain (see ParseEmail)
 func IsEmail(val string, params ...interface{}) bool {
-	// TODO uppercase letters are not supported
-	return rxEmail.MatchString(val)
+	_, err := ParseEmail(val)
+	return err == nil
 }
 
-// IsURL check if the string is an URL.
+// IsURL check if the string is an URL. Params are the allowed schemes, e.g. url(https); URLs without a scheme are
+// then invalid.
 func IsURL(str string, params ...interface{}) bool {
 
 	// don't invalidate for zero length. Use 'required' validator for that
@@ -61,6 +63,9 @@ func IsURL(str string, params ...interface{}) bool {
 	if strings.HasPrefix(u.Host, ".") {
 		return false
 	}
+	if len(params) > 0 && !isAllowedScheme(u.Scheme, params) {
+		return false
+	}
 	if u.Host == "" && (u.Path != "" && !strings.Contains(u.Path, ".")) {
 		return false
 	}
@@ -130,13 +135,6 @@ func IsName(str string, params ...interface{}) bool {
 	return rxName.MatchString(str)
 }
 
-func IsPhone(str string, params ...interface{}) bool {
-	if IsNull(str) {
-		return true
-	}
-	return rxPhone.MatchString(str)
-}
-
 func IsSkype(str string, params ...interface{}) bool {
 	if IsNull(str) {
 		return true
@@ -357,35 +355,6 @@ func Matches(str, pattern string) bool {
 	return match
 }
 
-// IsDivisibleBy check if the string is a number that's divisible by another.
-// If second argument is not valid integer or zero, it's return false.
-// Otherwise, if first argument is not valid integer or zero, it's return true (Invalid string converts to zero).
-/*
-func IsDivisibleBy(val interface{}, params ...interface{}) bool {
-
-	v := reflect.TypeOf(val)
-	switch v.Kind() {
-	case reflect.String:
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64 :
-	case reflect.Float32, reflect.Float64
-	}
-
-	val,ok = val.(float32)
-	if ok {
-		fmt.Println("IsFloat, float")
-		return true
-	}
-
-	f, _ := ToFloat(str)
-	p := int64(f)
-	q, _ := ToInt(num)
-	if q == 0 {
-		return false
-	}
-	return (p == 0) || (p%q == 0)
-}
-*/
-
 func IsNonEmpty(val interface{}, params ...interface{}) bool {
 	switch t := val.(type) {
 	case int:
@@ -466,39 +435,6 @@ func IsUUID(str string, params ...interface{}) bool {
 	return rxUUID.MatchString(str)
 }
 
-// IsCreditCard check if the string is a credit card.
-func IsCreditCard(str string, params ...interface{}) bool {
-	r, _ := regexp.Compile("[^0-9]+")
-	sanitized := r.ReplaceAll([]byte(str), []byte(""))
-	if !rxCreditCard.MatchString(string(sanitized)) {
-		return false
-	}
-	var sum int64
-	var digit string
-	var tmpNum int64
-	var shouldDouble bool
-	for i := len(sanitized) - 1; i >= 0; i-- {
-		digit = string(sanitized[i:(i + 1)])
-		tmpNum, _ = toInt(digit)
-		if shouldDouble {
-			tmpNum *= 2
-			if tmpNum >= 10 {
-				sum += ((tmpNum % 10) + 1)
-			} else {
-				sum += tmpNum
-			}
-		} else {
-			sum += tmpNum
-		}
-		shouldDouble = !shouldDouble
-	}
-
-	if sum%10 == 0 {
-		return true
-	}
-	return false
-}
-
 // IsISBN10 check if the string is an ISBN version 10.
 func IsISBN10(str string, params ...interface{}) bool {
 	return IsISBN(str, 10)
@@ -659,6 +595,17 @@ func IsISO3166Alpha3(str string, params ...interface{}) bool {
 	return false
 }
 
+// countryAlpha2 returns the two-letter code of a country given as a two or three-letter code, in any case
+func countryAlpha2(str string) (string, bool) {
+	code := strings.ToUpper(strings.TrimSpace(str))
+	for _, entry := range ISO3166List {
+		if code == entry.Alpha2Code || code == entry.Alpha3Code {
+			return entry.Alpha2Code, true
+		}
+	}
+	return "", false
+}
+
 // IsDNSName will validate the given string as a DNS name
 func IsDNSName(str string, params ...interface{}) bool {
 	if str == "" || len(strings.Replace(str, ".", "", -1)) > 255 {
@@ -682,22 +629,10 @@ func IsIP(str string, params ...interface{}) bool {
 	return net.ParseIP(str) != nil
 }
 
-// IsPort checks if a string represents a valid port
+// IsPort checks if a string or a number of any kind represents a valid port
 func IsPort(val interface{}, params ...interface{}) bool {
-
-	switch val.(type) {
-	case string:
-		x, _ := val.(string)
-		if i, err := strconv.Atoi(x); err == nil && i > 0 && i < 65536 {
-			return true
-		}
-		return false
-	case int, int8, int16, int64, uint, uint8, uint16, uint32, uint64:
-		x, _ := val.(int)
-		return x > 0 && x < 65536
-	default:
-		return false
-	}
+	n, ok := numberOfValue(val)
+	return ok && n.isWhole() && numberBetween(n, number{kind: intNumber, i: 1}, number{kind: intNumber, i: 65535})
 }
 
 // IsIPv4 check if the string is an IP version 4.
@@ -827,96 +762,32 @@ func StringMatches(val string, params ...interface{}) bool {
 }
 
 // Between check params's length (including multi byte for strings) against supplied parameters. Parameters
-// are inclusive. Expects ints for params. Handles string, int types for val.
+// are inclusive. Handles strings, by length, and every numeric kind, by value. Params can be any number, e.g.
+// between(-1.5,1e20).
 func Between(val interface{}, params ...interface{}) bool {
 
-	// better to use between or min/max?
-	// between enforces min/max which is good
-	// but we can't tell user which is wrong
-	// possibly return 3 values?
-
 	if len(params) != 2 {
 		return false
 	}
 
-	// we ignore empty values in this validator. Otherwise, it'd be the same as required, which it's not.
-	// use required if you need required!
-	switch val.(type) {
-	case string:
-		x, _ := val.(string)
+	min, minOk := numberOfValue(params[0])
+	max, maxOk := numberOfValue(params[1])
+	if !minOk || !maxOk {
+		return false
+	}
 
+	// we ignore empty strings in this validator. Otherwise, it'd be the same as required, which it's not.
+	// use required if you need required! Zero is a number like any other though.
+	if x, ok := val.(string); ok {
 		if len(x) == 0 {
 			return true
 		}
-
-		min, max, err := minMaxToInt(params[0], params[1])
-		if err != nil {
-			return false
-		}
-
-		strLength := int64(utf8.RuneCountInString(x))
-		return strLength >= min && strLength <= max
-	case int, int8, int16, int64, uint, uint8, uint16, uint32, uint64:
-		x, _ := toInt(val)
-
-		if x == 0 {
-			return true
-		}
-
-		min, max, err := minMaxToInt(params[0], params[1])
-		if err != nil {
-			return false
-		}
-
-		return x >= min && x <= max
-	case float32, float64:
-		x, _ := toFloat(val)
-
-		if x == 0.0 {
-			return true
-		}
-
-		min, max, err := minMaxToFloat(params[0], params[1])
-		if err != nil {
-			return false
-		}
-
-		return x >= min && x <= max
-	//case time.Time:
-
-	default:
-		return false
-	}
-
-	return false
-}
-
-func minMaxToInt(min, max interface{}) (int64, int64, error) {
-	intMin, err := toInt(min)
-	if err != nil {
-		return 0, 0, err
-	}
-
-	intMax, err := toInt(max)
-	if err != nil {
-		return 0, 0, err
-	}
-
-	return intMin, intMax, nil
-}
-
-func minMaxToFloat(min, max interface{}) (float64, float64, error) {
-	floatMin, err := toFloat(min)
-	if err != nil {
-		return 0.0, 0.0, err
-	}
-
-	floatMax, err := toFloat(max)
-	if err != nil {
-		return 0.0, 0.0, err
+		strLength := number{kind: intNumber, i: int64(utf8.RuneCountInString(x))}
+		return numberBetween(strLength, min, max)
 	}
 
-	return floatMin, floatMax, nil
+	n, ok := numberOfValue(val)
+	return ok && numberBetween(n, min, max)
 }
 
 // Abs returns absolute value of number
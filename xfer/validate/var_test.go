@@ -0,0 +1,75 @@
+package validate
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateVar(t *testing.T) {
+	t.Parallel()
+
+	bag, err := ValidateVar("john@example.com", "required|email")
+	assert.Nil(t, err)
+	assert.False(t, bag.HasErrors(), bag.String())
+
+	bag, err = ValidateVar("not an email", "required|email|name=Email")
+	assert.Nil(t, err)
+	assert.True(t, bag.HasErrors())
+	assert.Equal(t, "email", bag.Errors()[0].Validator)
+	assert.Equal(t, "Email", bag.Errors()[0].Field)
+	assert.Equal(t, "", bag.Errors()[0].Path)
+
+	bag, err = ValidateVar(7, "between(1,5)")
+	assert.Nil(t, err)
+	assert.Equal(t, "Value must be between 1 and 5", bag.FirstErrorMessage())
+}
+
+func TestValidateVarNil(t *testing.T) {
+	t.Parallel()
+
+	var page *string
+
+	for _, value := range []interface{}{nil, page, ""} {
+		bag, err := ValidateVar(value, "required")
+		assert.Nil(t, err)
+		assert.True(t, bag.HasErrors())
+	}
+}
+
+func TestValidateVarInvalidTag(t *testing.T) {
+	t.Parallel()
+
+	_, err := ValidateVar("x", "required|nosuchrule")
+	assert.NotNil(t, err)
+}
+
+func TestValidateSlice(t *testing.T) {
+	t.Parallel()
+
+	emails := []string{"a@example.com", "b", "c@example.com", ""}
+
+	bag, err := ValidateSlice(emails, "required|email")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"[1]:email", "[3]:required", "[3]:email"}, embeddedErrorPaths(bag))
+
+	bag, err = ValidateSlice([]string{}, "required")
+	assert.Nil(t, err)
+	assert.False(t, bag.HasErrors())
+
+	bag, err = ValidateSlice(&[2]int{3, 30}, "between(1,10)")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"[1]:between"}, embeddedErrorPaths(bag))
+
+	_, err = ValidateSlice("a@example.com", "email")
+	assert.NotNil(t, err)
+}
+
+func TestValidateSliceContext(t *testing.T) {
+	t.Parallel()
+
+	bag, err := ValidateSliceContext(WithLocale(context.Background(), "de"), []string{""}, "required|name=E-Mail")
+	assert.Nil(t, err)
+	assert.Equal(t, "de", bag.Locale())
+	assert.True(t, bag.HasErrors())
+}
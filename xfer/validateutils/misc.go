@@ -2,7 +2,7 @@ package validateutils
 
 import (
 	"fmt"
-	"github.com/ttacon/libphonenumber"
+	"github.com/jjharr/genesis/xfer/validate"
 	"strings"
 )
 
@@ -10,8 +10,7 @@ func ValidPhoneNumber(phone, country string) (bool, string) {
 	if len(strings.TrimSpace(phone)) == 0 {
 		return false, "Empty phone number"
 	}
-	_, err := libphonenumber.Parse(phone, country)
-	if err != nil {
+	if _, ok := validate.ParsePhone(phone, country); !ok {
 		if len(country) == 0 {
 			return false, "Invalid phone number"
 		} else {
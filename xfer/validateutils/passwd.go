@@ -1,17 +1,34 @@
 package validateutils
 
-import "fmt"
+import "github.com/jjharr/genesis/xfer/validate"
 
 var (
-	MIN_PASSWORD_LEN = 10
-	MAX_PASSWORD_LEN = 60
+	// MIN_PASSWORD_LEN and MAX_PASSWORD_LEN are the password lengths ValidPassword accepts. They start as the
+	// lengths of the default password policy of the validate package.
+	//
+	// Deprecated: register a default policy with validate.RegisterPasswordPolicy instead, so the password rule
+	// uses the same lengths. ValidPassword applies these over the lengths of the registered policy.
+	MIN_PASSWORD_LEN = defaultPasswordPolicy().MinLength
+	MAX_PASSWORD_LEN = defaultPasswordPolicy().MaxLength
 )
 
+func defaultPasswordPolicy() validate.PasswordPolicy {
+	policy, _ := validate.GetPasswordPolicy(validate.DefaultPasswordPolicy)
+	return policy
+}
+
+// ValidPassword checks a password against the default password policy of the validate package, with the lengths
+// of MIN_PASSWORD_LEN and MAX_PASSWORD_LEN. The message lists the reasons the password was rejected.
 func ValidPassword(password string) (bool, string) {
-	if len(password) < MIN_PASSWORD_LEN {
-		return false, fmt.Sprintf("Password must be at least %d characters long", MIN_PASSWORD_LEN)
-	} else if len(password) > MAX_PASSWORD_LEN {
-		return false, fmt.Sprintf("Password must be less than %d characters long", MAX_PASSWORD_LEN)
+	policy := defaultPasswordPolicy()
+	policy.MinLength, policy.MaxLength = MIN_PASSWORD_LEN, MAX_PASSWORD_LEN
+
+	reasons, err := validate.CheckPassword(password, policy)
+	if err != nil {
+		return false, "Password could not be checked"
+	}
+	if len(reasons) == 0 {
+		return true, ""
 	}
-	return true, ""
+	return false, "Password is too weak: " + validate.PasswordReasonsMessage(reasons, validate.DefaultLocale)
 }
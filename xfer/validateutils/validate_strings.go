@@ -2,6 +2,7 @@ package validateutils
 
 import (
 	"fmt"
+	"github.com/jjharr/genesis/xfer/validate"
 	"strings"
 	"unicode"
 )
@@ -12,11 +13,13 @@ const (
 	basicLatinLetters = "qwertyuiopasdfghjklzxcvbnmQWERTYUIOPASDFGHJKLZXCVBNM"
 )
 
+// ContainsOnly checks that str only holds the characters of validChars. Like the other ContainsOnly* helpers, it
+// compares the NFC form of str and never accepts invisible characters (see validate.ContainsOnly). Combining marks
+// are only accepted after letters by the helpers that accept letters of any script (see
+// validate.ContainsOnlyLettersOr).
 func ContainsOnly(str, validChars string) (bool, string) {
-	for _, r := range str {
-		if !strings.ContainsRune(validChars, r) {
-			return false, fmt.Sprintf(`Must contain only one of the following characters "%s"`, validChars)
-		}
+	if !validate.ContainsOnly(str, func(r rune) bool { return strings.ContainsRune(validChars, r) }) {
+		return false, fmt.Sprintf(`Must contain only one of the following characters "%s"`, validChars)
 	}
 	return true, ""
 }
@@ -34,10 +37,8 @@ func ContainsOnlyLettersNumbersSpaces(str string) (bool, string) {
 }
 
 func ContainsOnlyBasicLatinLettersAndSpaces(str string) (bool, string) {
-	for _, r := range str {
-		if !unicode.IsSpace(r) && !strings.ContainsRune(basicLatinLetters, r) {
-			return false, "Must contain only basic latin letters, numbers or spaces"
-		}
+	if !validate.ContainsOnly(str, func(r rune) bool { return unicode.IsSpace(r) || strings.ContainsRune(basicLatinLetters, r) }) {
+		return false, "Must contain only basic latin letters, numbers or spaces"
 	}
 	return true, ""
 }
@@ -47,26 +48,26 @@ func ContainsOnlyLettersNumbersSpacesInterpunctions(str string) (bool, string) {
 }
 
 func ContainsOnlyLettersNumbersSpacesOr(str string, additionalChars string) (bool, string) {
-	for _, r := range str {
-		if !unicode.IsSpace(r) && !unicode.IsLetter(r) && !unicode.IsNumber(r) && !strings.ContainsRune(additionalChars, r) {
-			if len(additionalChars) == 0 {
-				return false, "Must contain only letters, numbers or spaces"
-			} else {
-				return false, `Must contain only letters, numbers, spaces or characters "` + additionalChars + `"`
-			}
+	if !validate.ContainsOnlyLettersOr(str, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsNumber(r) || strings.ContainsRune(additionalChars, r)
+	}) {
+		if len(additionalChars) == 0 {
+			return false, "Must contain only letters, numbers or spaces"
+		} else {
+			return false, `Must contain only letters, numbers, spaces or characters "` + additionalChars + `"`
 		}
 	}
 	return true, ""
 }
 
 func ContainsOnlyLettersNumbersOr(str string, additionalChars string) (bool, string) {
-	for _, r := range str {
-		if !unicode.IsLetter(r) && !unicode.IsNumber(r) && !strings.ContainsRune(additionalChars, r) {
-			if len(additionalChars) == 0 {
-				return false, "Must contain only letters, numbers or spaces"
-			} else {
-				return false, `Must contain only letters, numbers, spaces or characters "` + additionalChars + `"`
-			}
+	if !validate.ContainsOnlyLettersOr(str, func(r rune) bool {
+		return unicode.IsNumber(r) || strings.ContainsRune(additionalChars, r)
+	}) {
+		if len(additionalChars) == 0 {
+			return false, "Must contain only letters, numbers or spaces"
+		} else {
+			return false, `Must contain only letters, numbers, spaces or characters "` + additionalChars + `"`
 		}
 	}
 	return true, ""